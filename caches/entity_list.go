@@ -35,6 +35,15 @@ func (el *entityList[K, V]) removeEntity(entity *lruEntity[K, V]) {
 	}
 }
 func (el *entityList[K, V]) clear() {
+	// the links between the entities are broken, so each of them can be collected independently of the others
+	var zero V
+	for entity := el.head; entity != nil; {
+		next := entity.next
+		entity.prev = nil
+		entity.next = nil
+		entity.value = zero
+		entity = next
+	}
 	el.head = nil
 	el.tail = nil
 }
@@ -0,0 +1,13 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+// Entry is a key-value pair of a cache.
+//   - K - key type
+//   - V - value type
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
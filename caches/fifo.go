@@ -0,0 +1,97 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import "sync"
+
+// FIFOCache (first in, first out) is a cache that deletes the oldest inserted items.
+// Unlike the LRU, reading an item doesn't affect its eviction order.
+// The FIFOCache is safe for concurrent use by multiple goroutines.
+// - K - comparable key type
+// - V - value type
+type FIFOCache[K comparable, V any] struct {
+	mu       sync.RWMutex
+	mp       map[K]*lruEntity[K, V]
+	entities *entityList[K, V]
+	limit    int
+}
+
+// Put maps the specified key to the specified value.
+// If the key already exists, its value is replaced, but its insertion order is not changed.
+// If the limit is exceeded, the oldest inserted entry is evicted.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (fifo *FIFOCache[K, V]) Put(key K, value V) {
+	fifo.mu.Lock()
+	if entity, ok := fifo.mp[key]; ok {
+		entity.value = value
+	} else {
+		entity = &lruEntity[K, V]{key: key, value: value}
+		fifo.mp[key] = entity
+		fifo.entities.setHead(entity)
+		if len(fifo.mp) > fifo.limit {
+			fifo.evictEntity(fifo.entities.tail)
+		}
+	}
+	fifo.mu.Unlock()
+}
+
+func (fifo *FIFOCache[K, V]) evictEntity(entity *lruEntity[K, V]) {
+	fifo.entities.removeEntity(entity)
+	entity.prev = nil
+	entity.next = nil
+	delete(fifo.mp, entity.key)
+}
+
+// Get returns the value to which the specified key is mapped and the sign of existence of this value.
+// If a value for the key exists, its value is returned and true,
+// otherwise the default value for the value type is returned and false.
+// The eviction order of the cache entries isn't changed.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (fifo *FIFOCache[K, V]) Get(key K) (bool, V) {
+	var res V
+	fifo.mu.RLock()
+	entity, ok := fifo.mp[key]
+	if ok {
+		res = entity.value
+	}
+	fifo.mu.RUnlock()
+	return ok, res
+} //revive:enable:confusing-naming
+
+// Evict evicts the value to which the specified key is mapped.
+//   - key - the key that needs to be removed
+//
+//revive:disable:confusing-naming
+func (fifo *FIFOCache[K, V]) Evict(key K) (bool, V) {
+	var res V
+	fifo.mu.Lock()
+	entity, ok := fifo.mp[key]
+	if ok {
+		res = entity.value
+		fifo.evictEntity(entity)
+	}
+	fifo.mu.Unlock()
+	return ok, res
+} //revive:enable:confusing-naming
+
+// Size returns the number of cached values
+//
+//revive:disable:confusing-naming
+func (fifo *FIFOCache[K, V]) Size() int {
+	fifo.mu.RLock()
+	defer fifo.mu.RUnlock()
+	return len(fifo.mp)
+} //revive:enable:confusing-naming
+
+// NewFIFOCache creates and returns a new FIFO cache.
+// - limit - specifies the max number of key-value pairs that we want to keep.
+// - K - comparable key type
+// - V - value type
+func NewFIFOCache[K comparable, V any](limit int) *FIFOCache[K, V] {
+	return &FIFOCache[K, V]{mp: make(map[K]*lruEntity[K, V], limit), entities: &entityList[K, V]{}, limit: limit}
+}
@@ -0,0 +1,77 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFIFOCache_Put(t *testing.T) {
+	fifo := NewFIFOCache[int, string](testLruLimit)
+	for i := 1; i <= testLruLimit; i++ {
+		fifo.Put(i, fmt.Sprint("value ", i))
+	}
+	fifo.Put(1, "updated")
+	assert.Equal(t, testLruLimit, fifo.Size())
+	ok, actual := fifo.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "updated", actual)
+
+	fifo.Put(testLruLimit+1, "new")
+	assert.Equal(t, testLruLimit, fifo.Size())
+	ok, _ = fifo.Get(1)
+	assert.False(t, ok, "updating should not save the oldest entry from eviction")
+	ok, actual = fifo.Get(testLruLimit + 1)
+	assert.True(t, ok)
+	assert.Equal(t, "new", actual)
+}
+
+func TestFIFOCache_Get_no_reorder(t *testing.T) {
+	fifo := NewFIFOCache[int, string](testLruLimit)
+	lru := NewLRU[int, string](testLruLimit)
+	for i := 1; i <= testLruLimit; i++ {
+		fifo.Put(i, fmt.Sprint("value ", i))
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	for i := 0; i < 5; i++ {
+		fifo.Get(1)
+		lru.Get(1)
+	}
+	fifo.Put(100, "value 100")
+	lru.Put(100, "value 100")
+
+	ok, _ := fifo.Get(1)
+	assert.False(t, ok, "the oldest entry should be evicted despite being read")
+	ok, _ = fifo.Get(2)
+	assert.True(t, ok)
+
+	ok, _ = lru.Get(1)
+	assert.True(t, ok, "LRU keeps the recently read entry")
+	ok, _ = lru.Get(2)
+	assert.False(t, ok)
+}
+
+func TestFIFOCache_Evict(t *testing.T) {
+	fifo := NewFIFOCache[int, string](testLruLimit)
+	for i := 1; i <= testLruLimit; i++ {
+		fifo.Put(i, fmt.Sprint("value ", i))
+	}
+	ok, actual := fifo.Evict(2)
+	assert.True(t, ok)
+	assert.Equal(t, "value 2", actual)
+	ok, _ = fifo.Evict(2)
+	assert.False(t, ok)
+	assert.Equal(t, testLruLimit-1, fifo.Size())
+
+	fifo.Put(10, "value 10")
+	fifo.Put(11, "value 11")
+	ok, _ = fifo.Get(1)
+	assert.False(t, ok)
+	ok, _ = fifo.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, testLruLimit, fifo.Size())
+}
@@ -0,0 +1,65 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import "sync"
+
+// IdempotencyCache is a bounded set of the seen keys, e.g. the IDs of the processed requests.
+// When the capacity is exceeded, the oldest seen keys are evicted (FIFO),
+// seeing a key again doesn't change its eviction order.
+// The IdempotencyCache is safe for concurrent use by multiple goroutines.
+// - K - comparable key type
+type IdempotencyCache[K comparable] struct {
+	mu       sync.Mutex
+	mp       map[K]*lruEntity[K, struct{}]
+	entities *entityList[K, struct{}]
+	capacity int
+}
+
+// Seen returns true if the specified key was seen before and hasn't been evicted yet,
+// otherwise records the key and returns false.
+// The check and the recording are performed atomically, so only one of the concurrent calls
+// with the same key returns false.
+//   - key - the key to be checked
+func (icache *IdempotencyCache[K]) Seen(key K) bool {
+	icache.mu.Lock()
+	defer icache.mu.Unlock()
+	if _, ok := icache.mp[key]; ok {
+		return true
+	}
+	entity := &lruEntity[K, struct{}]{key: key}
+	icache.mp[key] = entity
+	icache.entities.setHead(entity)
+	if len(icache.mp) > icache.capacity {
+		oldest := icache.entities.tail
+		icache.entities.removeEntity(oldest)
+		oldest.prev = nil
+		oldest.next = nil
+		delete(icache.mp, oldest.key)
+	}
+	return false
+}
+
+// Size returns the number of the recorded keys
+//
+//revive:disable:confusing-naming
+func (icache *IdempotencyCache[K]) Size() int {
+	icache.mu.Lock()
+	defer icache.mu.Unlock()
+	return len(icache.mp)
+} //revive:enable:confusing-naming
+
+// NewIdempotencyCache creates and returns a new IdempotencyCache.
+// A capacity less than 1 is treated as 1.
+// - capacity - specifies the max number of the keys that we want to keep.
+// - K - comparable key type
+func NewIdempotencyCache[K comparable](capacity int) *IdempotencyCache[K] {
+	capacity = max(capacity, 1)
+	return &IdempotencyCache[K]{
+		mp:       make(map[K]*lruEntity[K, struct{}], capacity),
+		entities: &entityList[K, struct{}]{},
+		capacity: capacity,
+	}
+}
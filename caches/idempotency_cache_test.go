@@ -0,0 +1,45 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIdempotencyCache_Seen(t *testing.T) {
+	icache := NewIdempotencyCache[string](3)
+	assert.False(t, icache.Seen("req-1"))
+	assert.True(t, icache.Seen("req-1"))
+	assert.False(t, icache.Seen("req-2"))
+	assert.False(t, icache.Seen("req-3"))
+	assert.True(t, icache.Seen("req-1"))
+	assert.Equal(t, 3, icache.Size())
+
+	assert.False(t, icache.Seen("req-4"))
+	assert.Equal(t, 3, icache.Size())
+	assert.False(t, icache.Seen("req-1"), "the oldest key should be evicted")
+	assert.False(t, icache.Seen("req-2"), "the oldest key should be evicted")
+	assert.True(t, icache.Seen("req-4"))
+}
+
+func TestIdempotencyCache_Seen_concurrent(t *testing.T) {
+	icache := NewIdempotencyCache[int](100)
+	var firstSeen atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !icache.Seen(1) {
+				firstSeen.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), firstSeen.Load())
+}
@@ -6,6 +6,7 @@
 package caches
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 )
@@ -19,45 +20,166 @@ type LRU[K comparable, V any] struct {
 	mp       map[K]*lruEntity[K, V]
 	entities *entityList[K, V]
 	limit    int
+	hooks    lruHooks[K, V]
 }
 
 // Put maps the specified key to the specified value
 //   - key - the key with which a specified value is to be assigned
 //   - value - the value to be associated with the specified key
 func (lru *LRU[K, V]) Put(key K, value V) {
+	var evicted *lruEntity[K, V]
 	lru.mu.Lock()
 	entity, ok := lru.mp[key]
 	if !ok {
 		entity = &lruEntity[K, V]{key: key, value: value}
-		lru.putEntity(entity)
+		evicted = lru.putEntity(entity)
 	} else {
 		entity.value = value
 		lru.entities.moveToHead(entity)
 	}
 	lru.mu.Unlock()
+	lru.hooks.evicted(evicted)
 }
-func (lru *LRU[K, V]) putEntity(entity *lruEntity[K, V]) {
+
+// PutAndReport does the same thing as Put, but also reports whether the call caused an eviction.
+// Returns true and the key of the evicted entry if an entry was evicted,
+// otherwise returns false and the default value for the key type.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (lru *LRU[K, V]) PutAndReport(key K, value V) (evicted bool, evictedKey K) {
+	var tail *lruEntity[K, V]
+	lru.mu.Lock()
+	if entity, ok := lru.mp[key]; ok {
+		entity.value = value
+		lru.entities.moveToHead(entity)
+	} else {
+		tail = lru.putEntity(&lruEntity[K, V]{key: key, value: value})
+	}
+	lru.mu.Unlock()
+	if tail == nil {
+		return false, evictedKey
+	}
+	lru.hooks.evicted(tail)
+	return true, tail.key
+}
+
+// PutAllReport maps all the specified keys to the corresponding values under a single lock
+// and returns the entries that were evicted as a result of the call, in the eviction order.
+// The keys that already exist are updated first, then the new keys are put in an unspecified order,
+// so an evicted key is never put back by the same call. If the number of the specified entries
+// exceeds the limit, some of them may be evicted too, use PutAllReportOrdered to control which ones.
+//   - entries - the key-value pairs to be put into the cache
+func (lru *LRU[K, V]) PutAllReport(entries map[K]V) []Entry[K, V] {
+	ordered := make([]Entry[K, V], 0, len(entries))
+	for key, value := range entries {
+		ordered = append(ordered, Entry[K, V]{Key: key, Value: value})
+	}
+	return lru.PutAllReportOrdered(ordered)
+}
+
+// PutAllReportOrdered does the same thing as PutAllReport, but the new keys are put in the order of the entries,
+// so the eviction order is deterministic. If a key occurs several times, the last value is used.
+//   - entries - the key-value pairs to be put into the cache
+func (lru *LRU[K, V]) PutAllReportOrdered(entries []Entry[K, V]) []Entry[K, V] {
+	var evicted []Entry[K, V]
+	lru.mu.Lock()
+	added := make([]Entry[K, V], 0, len(entries))
+	positions := make(map[K]int)
+	for _, entry := range entries {
+		if entity, ok := lru.mp[entry.Key]; ok {
+			entity.value = entry.Value
+			lru.entities.moveToHead(entity)
+		} else if pos, ok := positions[entry.Key]; ok {
+			added[pos].Value = entry.Value
+		} else {
+			positions[entry.Key] = len(added)
+			added = append(added, entry)
+		}
+	}
+	for _, entry := range added {
+		if tail := lru.putEntity(&lruEntity[K, V]{key: entry.Key, value: entry.Value}); tail != nil {
+			evicted = append(evicted, Entry[K, V]{Key: tail.key, Value: tail.value})
+		}
+	}
+	lru.mu.Unlock()
+	lru.hooks.evictedEntries(evicted)
+	return evicted
+}
+
+// PutIfRoom does the same thing as Put, but only if it does not cause an eviction,
+// i.e. the key already exists or the cache size is less than the limit.
+// Returns true if the value was mapped to the key, otherwise returns false and the cache is not changed.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (lru *LRU[K, V]) PutIfRoom(key K, value V) bool {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	entity, ok := lru.mp[key]
+	if ok {
+		entity.value = value
+		lru.entities.moveToHead(entity)
+		return true
+	}
+	if len(lru.mp) >= lru.limit {
+		return false
+	}
+	lru.putEntity(&lruEntity[K, V]{key: key, value: value})
+	return true
+}
+
+// putEntity puts the entity at the head of the list and evicts the tail entity if the limit is exceeded.
+// Returns the evicted entity or nil.
+func (lru *LRU[K, V]) putEntity(entity *lruEntity[K, V]) *lruEntity[K, V] {
 	lru.mp[entity.key] = entity
 	lru.entities.setHead(entity)
 	if len(lru.mp) > lru.limit {
-		lru.evictEntity(lru.entities.tail)
+		tail := lru.entities.tail
+		lru.evictEntity(tail)
+		return tail
 	}
+	return nil
 }
 
 // PutIfAbsent maps the specified key to the specified value
 // if the key doesn't exist returns true and a new value.
 // If the key exists, the new value will not be mapped to it, the method returns false and the previous key value.
+// Note! If the key exists, it is NOT marked as the most recently used one, use GetOrPut to do this.
 //   - key - the key with which a specified value is to be assigned
 //   - value - the value to be associated with the specified key
 func (lru *LRU[K, V]) PutIfAbsent(key K, value V) (bool, V) {
+	var evicted *lruEntity[K, V]
 	lru.mu.Lock()
 	entity, ok := lru.mp[key]
 	if !ok {
 		entity = &lruEntity[K, V]{key: key, value: value}
-		lru.putEntity(entity)
+		evicted = lru.putEntity(entity)
 	}
+	value = entity.value
 	lru.mu.Unlock()
-	return !ok, entity.value
+	lru.hooks.evicted(evicted)
+	return !ok, value
+}
+
+// GetOrPut does the same thing as PutIfAbsent, but if the key exists,
+// it is marked as the most recently used one, just like Get does.
+// If the key doesn't exist, the method maps the key to the value and returns true and the new value,
+// otherwise it returns false and the existing value.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (lru *LRU[K, V]) GetOrPut(key K, value V) (bool, V) {
+	var evicted *lruEntity[K, V]
+	lru.mu.Lock()
+	entity, ok := lru.mp[key]
+	if ok {
+		lru.entities.moveToHead(entity)
+	} else {
+		entity = &lruEntity[K, V]{key: key, value: value}
+		evicted = lru.putEntity(entity)
+	}
+	value = entity.value
+	lru.mu.Unlock()
+	lru.hooks.evicted(evicted)
+	return !ok, value
 }
 
 func (lru *LRU[K, V]) evictEntity(entity *lruEntity[K, V]) {
@@ -80,9 +202,39 @@ func (lru *LRU[K, V]) Get(key K) (bool, V) {
 		lru.entities.moveToHead(entity)
 	}
 	lru.mu.Unlock()
+	lru.hooks.accessed(key, res, ok)
 	return ok, res
 }
 
+// GetIfPresent returns the value to which the specified key is mapped and true if the value exists,
+// otherwise the default value for the value type and false are returned.
+// Unlike Get, this method does not mark the key as the most recently used one.
+//   - key - the key whose value will be returned
+func (lru *LRU[K, V]) GetIfPresent(key K) (V, bool) {
+	var res V
+	lru.mu.RLock()
+	entity, ok := lru.mp[key]
+	if ok {
+		res = entity.value
+	}
+	lru.mu.RUnlock()
+	lru.hooks.accessed(key, res, ok)
+	return res, ok
+}
+
+// Touch marks the specified key as the most recently used one without reading its value.
+// Returns true if the key exists, otherwise returns false.
+//   - key - the key to be marked as the most recently used one
+func (lru *LRU[K, V]) Touch(key K) bool {
+	lru.mu.Lock()
+	entity, ok := lru.mp[key]
+	if ok {
+		lru.entities.moveToHead(entity)
+	}
+	lru.mu.Unlock()
+	return ok
+}
+
 // Evict evicts the value to which the specified key is mapped.
 //   - key - the key that needs to be removed
 func (lru *LRU[K, V]) Evict(key K) (bool, V) {
@@ -94,9 +246,54 @@ func (lru *LRU[K, V]) Evict(key K) (bool, V) {
 		lru.evictEntity(entity)
 	}
 	lru.mu.Unlock()
+	if ok {
+		lru.hooks.evicted(entity)
+	}
 	return ok, res
 }
 
+// EvictN evicts up to n least recently used entries from the cache.
+// Returns the evicted entries in the eviction order (from the least recently used one).
+//   - n - the number of entries to be evicted, it is clamped to the range [0, Size()]
+func (lru *LRU[K, V]) EvictN(n int) []Entry[K, V] {
+	lru.mu.Lock()
+	n = max(0, min(n, len(lru.mp)))
+	result := make([]Entry[K, V], 0, n)
+	for i := 0; i < n; i++ {
+		entity := lru.entities.tail
+		result = append(result, Entry[K, V]{Key: entity.key, Value: entity.value})
+		lru.evictEntity(entity)
+	}
+	lru.mu.Unlock()
+	lru.hooks.evictedEntries(result)
+	return result
+}
+
+// GetV does the same thing as Get, but returns the value and the sign of its existence
+// in the conventional Go (value, ok) order.
+//   - key - the key whose value will be returned
+func (lru *LRU[K, V]) GetV(key K) (V, bool) {
+	ok, value := lru.Get(key)
+	return value, ok
+}
+
+// EvictV does the same thing as Evict, but returns the value and the sign of its existence
+// in the conventional Go (value, ok) order.
+//   - key - the key that needs to be removed
+func (lru *LRU[K, V]) EvictV(key K) (V, bool) {
+	ok, value := lru.Evict(key)
+	return value, ok
+}
+
+// PutIfAbsentV does the same thing as PutIfAbsent, but returns the value and the sign of its addition
+// in the conventional Go (value, ok) order.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (lru *LRU[K, V]) PutIfAbsentV(key K, value V) (V, bool) {
+	ok, val := lru.PutIfAbsent(key, value)
+	return val, ok
+}
+
 // Copy returns a shallow copy of this LRU cache instance: the keys and the values themselves are not copies.
 func (lru *LRU[K, V]) Copy() map[K]V {
 	lru.mu.RLock()
@@ -108,7 +305,79 @@ func (lru *LRU[K, V]) Copy() map[K]V {
 	return result
 }
 
+// Clone returns a new independent LRU cache with the same limit, the same hooks (see NewLRUWithOptions)
+// and the same entries in the same recency order. The keys and the values themselves are not copies.
+func (lru *LRU[K, V]) Clone() *LRU[K, V] {
+	lru.mu.RLock()
+	result := NewLRU[K, V](lru.limit)
+	result.hooks = lru.hooks
+	for entity := lru.entities.tail; entity != nil; entity = entity.prev {
+		clone := &lruEntity[K, V]{key: entity.key, value: entity.value}
+		result.mp[clone.key] = clone
+		result.entities.setHead(clone)
+	}
+	lru.mu.RUnlock()
+	return result
+}
+
+// Values returns a slice of the values contained in this cache
+// ordered from the most recently used to the least recently used one.
+// The recency order of the cache entries isn't changed.
+func (lru *LRU[K, V]) Values() []V {
+	lru.mu.RLock()
+	result := make([]V, 0, len(lru.mp))
+	for entity := lru.entities.head; entity != nil; entity = entity.next {
+		result = append(result, entity.value)
+	}
+	lru.mu.RUnlock()
+	return result
+}
+
+// TopN returns up to n most recently used entries ordered from the most recently used one.
+// Only n entries are visited, and the recency order of the cache entries isn't changed.
+//   - n - the number of entries to be returned, it is clamped to the range [0, Size()]
+func (lru *LRU[K, V]) TopN(n int) []Entry[K, V] {
+	lru.mu.RLock()
+	n = max(0, min(n, len(lru.mp)))
+	result := make([]Entry[K, V], 0, n)
+	for entity := lru.entities.head; len(result) < n; entity = entity.next {
+		result = append(result, Entry[K, V]{Key: entity.key, Value: entity.value})
+	}
+	lru.mu.RUnlock()
+	return result
+}
+
+// ForEach performs a given action for each (key, value) pair of the cache
+// from the most recently used to the least recently used one.
+// The recency order of the cache entries isn't changed.
+//   - f - the function, that will be called for each (key, value) pair
+//
+// Note! A read lock is used under the hood, so you should not use methods that modify the cache
+// (including Get) inside the 'f' function, as this will cause a deadlock.
+//
+//revive:disable:confusing-naming
+func (lru *LRU[K, V]) ForEach(f func(key K, value V)) {
+	lru.mu.RLock()
+	for entity := lru.entities.head; entity != nil; entity = entity.next {
+		f(entity.key, entity.value)
+	}
+	lru.mu.RUnlock()
+} //revive:enable:confusing-naming
+
+// ForEachReverse does the same thing as ForEach, but in the reverse order:
+// from the least recently used (the next eviction candidate) to the most recently used one.
+//   - f - the function, that will be called for each (key, value) pair
+func (lru *LRU[K, V]) ForEachReverse(f func(key K, value V)) {
+	lru.mu.RLock()
+	for entity := lru.entities.tail; entity != nil; entity = entity.prev {
+		f(entity.key, entity.value)
+	}
+	lru.mu.RUnlock()
+}
+
 // Clear clears the cache.
+// The links between the removed entries are broken, so each of them can be collected by the garbage collector
+// independently of the others. Therefore, the complexity of this method is O(n).
 //
 //revive:disable:confusing-naming
 func (lru *LRU[K, V]) Clear() {
@@ -125,6 +394,62 @@ func (lru *LRU[K, V]) Size() int {
 	return len(lru.mp)
 }
 
+// Limit returns the max number of key-value mappings that this cache can hold.
+func (lru *LRU[K, V]) Limit() int {
+	lru.mu.RLock()
+	defer lru.mu.RUnlock()
+	return lru.limit
+}
+
+// Utilization returns the ratio of the number of key-value mappings in this cache to its limit
+// or 0 if the limit is not positive.
+func (lru *LRU[K, V]) Utilization() float64 {
+	lru.mu.RLock()
+	defer lru.mu.RUnlock()
+	if lru.limit <= 0 {
+		return 0
+	}
+	return float64(len(lru.mp)) / float64(lru.limit)
+}
+
+// Validate checks the internal consistency of this cache and returns an error describing
+// the first inconsistency found or nil if the cache is consistent.
+// It is intended for diagnostics and tests, the complexity of this method is O(n).
+func (lru *LRU[K, V]) Validate() error {
+	lru.mu.RLock()
+	defer lru.mu.RUnlock()
+	head, tail := lru.entities.head, lru.entities.tail
+	if (head == nil) != (tail == nil) {
+		return errors.New("only one of the head and the tail of the list exists")
+	}
+	if head != nil && head.prev != nil {
+		return errors.New("the head entity has a previous entity")
+	}
+	if tail != nil && tail.next != nil {
+		return errors.New("the tail entity has a next entity")
+	}
+	count := 0
+	for entity := head; entity != nil; entity = entity.next {
+		if lru.mp[entity.key] != entity {
+			return fmt.Errorf("the entity %v is not in the map", entity)
+		}
+		if entity.next != nil && entity.next.prev != entity {
+			return fmt.Errorf("the entity %v is not the previous entity of its next entity", entity)
+		}
+		if entity.next == nil && entity != tail {
+			return fmt.Errorf("the entity %v has no next entity, but it is not the tail", entity)
+		}
+		count++
+		if count > len(lru.mp) {
+			return fmt.Errorf("the list contains more entities than the map size %d", len(lru.mp))
+		}
+	}
+	if count != len(lru.mp) {
+		return fmt.Errorf("the list contains %d entities, the map size is %d", count, len(lru.mp))
+	}
+	return nil
+}
+
 // String prints the LRU cache limit value and the number of key-value mappings in this cache
 func (lru *LRU[K, V]) String() string {
 	lru.mu.RLock()
@@ -0,0 +1,82 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+// LRUOption is an option of the LRU cache, see NewLRUWithOptions.
+//   - K - comparable key type
+//   - V - value type
+type LRUOption[K comparable, V any] func(lru *LRU[K, V])
+
+// lruHooks contains the functions that are called on the LRU cache events.
+// The hooks are called outside the lock, so the LRU methods can be used inside them.
+type lruHooks[K comparable, V any] struct {
+	onHit   func(key K, value V)
+	onMiss  func(key K)
+	onEvict func(key K, value V)
+}
+
+func (hooks *lruHooks[K, V]) accessed(key K, value V, hit bool) {
+	if hit {
+		if hooks.onHit != nil {
+			hooks.onHit(key, value)
+		}
+	} else if hooks.onMiss != nil {
+		hooks.onMiss(key)
+	}
+}
+
+func (hooks *lruHooks[K, V]) evicted(entity *lruEntity[K, V]) {
+	if entity != nil && hooks.onEvict != nil {
+		hooks.onEvict(entity.key, entity.value)
+	}
+}
+
+func (hooks *lruHooks[K, V]) evictedEntries(entries []Entry[K, V]) {
+	if hooks.onEvict == nil {
+		return
+	}
+	for _, entry := range entries {
+		hooks.onEvict(entry.Key, entry.Value)
+	}
+}
+
+// WithOnHit sets the function that is called when a value is found by Get, GetV or GetIfPresent.
+//   - onHit - the function that receives the key and the found value
+func WithOnHit[K comparable, V any](onHit func(key K, value V)) LRUOption[K, V] {
+	return func(lru *LRU[K, V]) {
+		lru.hooks.onHit = onHit
+	}
+}
+
+// WithOnMiss sets the function that is called when a value isn't found by Get, GetV or GetIfPresent.
+//   - onMiss - the function that receives the key whose value wasn't found
+func WithOnMiss[K comparable, V any](onMiss func(key K)) LRUOption[K, V] {
+	return func(lru *LRU[K, V]) {
+		lru.hooks.onMiss = onMiss
+	}
+}
+
+// WithOnEvict sets the function that is called for each entry that is evicted from the cache,
+// either because the limit is exceeded or explicitly by Evict, EvictV and EvictN.
+// It is not called when the cache is cleared.
+//   - onEvict - the function that receives the key and the value of the evicted entry
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V)) LRUOption[K, V] {
+	return func(lru *LRU[K, V]) {
+		lru.hooks.onEvict = onEvict
+	}
+}
+
+// NewLRUWithOptions creates and returns a new LRU cache configured with the specified options.
+// The hooks set by the options are called after the operation that caused them, outside the lock,
+// so the LRU methods can be used inside them. Without options, it is the same as NewLRU.
+// - limit - specifies the max number of key-value pairs that we want to keep.
+// - opts - the options of the cache, e.g. WithOnHit, WithOnMiss, WithOnEvict
+func NewLRUWithOptions[K comparable, V any](limit int, opts ...LRUOption[K, V]) *LRU[K, V] {
+	lru := NewLRU[K, V](limit)
+	for _, opt := range opts {
+		opt(lru)
+	}
+	return lru
+}
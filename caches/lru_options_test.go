@@ -0,0 +1,103 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewLRUWithOptions_hooks(t *testing.T) {
+	var hits, evictions []Entry[int, string]
+	var misses []int
+	var lru *LRU[int, string]
+	lru = NewLRUWithOptions[int, string](testLruLimit,
+		WithOnHit(func(key int, value string) {
+			hits = append(hits, Entry[int, string]{Key: key, Value: value})
+		}),
+		WithOnMiss[int, string](func(key int) {
+			misses = append(misses, key)
+		}),
+		WithOnEvict(func(key int, value string) {
+			assert.LessOrEqual(t, lru.Size(), testLruLimit, "the hook should be called outside the lock")
+			evictions = append(evictions, Entry[int, string]{Key: key, Value: value})
+		}),
+	)
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	assert.Empty(t, evictions)
+
+	lru.Get(1)
+	lru.Get(100)
+	assert.Equal(t, []Entry[int, string]{{Key: 1, Value: "value 1"}}, hits)
+	assert.Equal(t, []int{100}, misses)
+
+	lru.Put(4, "value 4")
+	assert.Equal(t, []Entry[int, string]{{Key: 2, Value: "value 2"}}, evictions)
+
+	evictions = nil
+	lru.Put(5, "value 5")
+	lru.Evict(5)
+	assert.Equal(t, []Entry[int, string]{{Key: 3, Value: "value 3"}}, evictions[:1])
+	assert.Equal(t, 2, len(evictions))
+	assert.Equal(t, Entry[int, string]{Key: 5, Value: "value 5"}, evictions[1])
+}
+
+func TestNewLRUWithOptions_EvictN(t *testing.T) {
+	var evicted []int
+	lru := NewLRUWithOptions[int, string](testLruLimit, WithOnEvict(func(key int, value string) {
+		evicted = append(evicted, key)
+	}))
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	lru.EvictN(2)
+	assert.Equal(t, []int{1, 2}, evicted)
+	lru.Clear()
+	assert.Equal(t, []int{1, 2}, evicted)
+}
+
+func TestNewLRUWithOptions_no_options(t *testing.T) {
+	lru := NewLRUWithOptions[int, string](testLruLimit)
+	expected := NewLRU[int, string](testLruLimit)
+	for i := 1; i <= testLruLimit+2; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+		expected.Put(i, fmt.Sprint("value ", i))
+	}
+	lru.Get(3)
+	expected.Get(3)
+	lru.Get(1)
+	expected.Get(1)
+	assert.Equal(t, expected.Limit(), lru.Limit())
+	assert.Equal(t, expected.Values(), lru.Values())
+	assert.Equal(t, expected.Copy(), lru.Copy())
+}
+
+func TestNewLRUWithOptions_Clone(t *testing.T) {
+	var hits, misses, evicted []int
+	lru := NewLRUWithOptions[int, string](testLruLimit,
+		WithOnHit(func(key int, value string) {
+			hits = append(hits, key)
+		}),
+		WithOnMiss[int, string](func(key int) {
+			misses = append(misses, key)
+		}),
+		WithOnEvict(func(key int, value string) {
+			evicted = append(evicted, key)
+		}),
+	)
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	clone := lru.Clone()
+	clone.Get(2)
+	clone.Get(100)
+	clone.Put(4, "value 4")
+	assert.Equal(t, []int{2}, hits, "the clone should keep the OnHit hook")
+	assert.Equal(t, []int{100}, misses, "the clone should keep the OnMiss hook")
+	assert.Equal(t, []int{1}, evicted, "the clone should keep the OnEvict hook")
+}
@@ -1,8 +1,12 @@
 package caches
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 )
 
 const testLruLimit = 3
@@ -213,6 +217,382 @@ func TestLRU_Put(t *testing.T) {
 	assert.Equal(t, testLruLimit, lru.Size())
 }
 
+func TestLRU_Touch(t *testing.T) {
+	lru := createTestLru()
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+
+	assert.True(t, lru.Touch(1))
+	assert.False(t, lru.Touch(123))
+	assert.Equal(t, "value1", lru.entities.head.value)
+
+	lru.Put(4, "value4")
+
+	ok, val := lru.Get(1)
+	assert.True(t, ok, "the touched key must not be evicted")
+	assert.Equal(t, "value1", val)
+	ok, _ = lru.Get(2)
+	assert.False(t, ok, "the least recently used key must be evicted")
+	assert.Equal(t, testLruLimit, lru.Size())
+}
+
+func TestLRU_Values(t *testing.T) {
+	lru := createTestLru()
+	assert.Empty(t, lru.Values())
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+	lru.Get(1)
+	assert.Equal(t, []string{"value1", "value3", "value2"}, lru.Values())
+	assert.Equal(t, []string{"value1", "value3", "value2"}, lru.Values(), "Values() must not change the order")
+}
+
+func TestLRU_Limit(t *testing.T) {
+	assert.Equal(t, testLruLimit, createTestLru().Limit())
+	assert.Equal(t, 100, NewLRU[int, int](100).Limit())
+}
+
+func TestLRU_EvictN(t *testing.T) {
+	lru := NewLRU[int, string](5)
+	for i := 1; i <= 5; i++ {
+		lru.Put(i, fmt.Sprint("value", i))
+	}
+	lru.Get(1)
+
+	evicted := lru.EvictN(2)
+
+	assert.Equal(t, []Entry[int, string]{{Key: 2, Value: "value2"}, {Key: 3, Value: "value3"}}, evicted)
+	assert.Equal(t, 3, lru.Size())
+	assert.Equal(t, []string{"value1", "value5", "value4"}, lru.Values())
+}
+
+func TestLRU_EvictN_clamp(t *testing.T) {
+	lru := createTestLru()
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	assert.Empty(t, lru.EvictN(-1))
+	assert.Empty(t, lru.EvictN(0))
+	assert.Equal(t, 2, lru.Size())
+	evicted := lru.EvictN(10)
+	assert.Equal(t, []Entry[int, string]{{Key: 1, Value: "value1"}, {Key: 2, Value: "value2"}}, evicted)
+	assert.Equal(t, 0, lru.Size())
+	assert.Nil(t, lru.entities.head)
+	assert.Nil(t, lru.entities.tail)
+}
+
+func TestLRU_PutIfAbsent_does_not_promote(t *testing.T) {
+	lru := createTestLru()
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+
+	ok, val := lru.PutIfAbsent(1, "other value1")
+
+	assert.False(t, ok)
+	assert.Equal(t, "value1", val)
+	assert.Equal(t, "value1", lru.entities.tail.value, "PutIfAbsent must not promote an existing key")
+	lru.Put(4, "value4")
+	ok, _ = lru.Get(1)
+	assert.False(t, ok, "the existing key must be evicted as the least recently used one")
+}
+
+func TestLRU_GetOrPut(t *testing.T) {
+	lru := createTestLru()
+	ok, val := lru.GetOrPut(1, "value1")
+	assert.True(t, ok)
+	assert.Equal(t, "value1", val)
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+
+	ok, val = lru.GetOrPut(1, "other value1")
+
+	assert.False(t, ok)
+	assert.Equal(t, "value1", val)
+	assert.Equal(t, "value1", lru.entities.head.value, "GetOrPut must promote an existing key")
+	lru.Put(4, "value4")
+	ok, _ = lru.Get(1)
+	assert.True(t, ok)
+	ok, _ = lru.Get(2)
+	assert.False(t, ok)
+	assert.Equal(t, testLruLimit, lru.Size())
+}
+
+func TestLRU_Utilization(t *testing.T) {
+	lru := NewLRU[int, string](4)
+	assert.Equal(t, 0.0, lru.Utilization())
+	lru.Put(1, "value1")
+	assert.Equal(t, 0.25, lru.Utilization())
+	lru.Put(2, "value2")
+	assert.Equal(t, 0.5, lru.Utilization())
+	lru.Put(3, "value3")
+	lru.Put(4, "value4")
+	assert.Equal(t, 1.0, lru.Utilization())
+	lru.Put(5, "value5")
+	assert.Equal(t, 1.0, lru.Utilization())
+	assert.Equal(t, 0.0, NewLRU[int, string](0).Utilization())
+}
+
+func TestLRU_GetIfPresent(t *testing.T) {
+	lru := createTestLru()
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+
+	val, ok := lru.GetIfPresent(1)
+	assert.True(t, ok)
+	assert.Equal(t, "value1", val)
+	assert.Equal(t, []string{"value3", "value2", "value1"}, lru.Values(), "the recency order must not be changed")
+
+	val, ok = lru.GetIfPresent(123)
+	assert.False(t, ok)
+	assert.Equal(t, "", val)
+}
+
+func TestLRU_value_first_methods(t *testing.T) {
+	lru := createTestLru()
+
+	val, ok := lru.PutIfAbsentV(1, "value1")
+	assert.True(t, ok)
+	assert.Equal(t, "value1", val)
+	val, ok = lru.PutIfAbsentV(1, "other value1")
+	assert.False(t, ok)
+	assert.Equal(t, "value1", val)
+
+	val, ok = lru.GetV(1)
+	assert.True(t, ok)
+	assert.Equal(t, "value1", val)
+	val, ok = lru.GetV(123)
+	assert.False(t, ok)
+	assert.Equal(t, "", val)
+
+	val, ok = lru.EvictV(1)
+	assert.True(t, ok)
+	assert.Equal(t, "value1", val)
+	val, ok = lru.EvictV(1)
+	assert.False(t, ok)
+	assert.Equal(t, "", val)
+	assert.Equal(t, 0, lru.Size())
+}
+
+func TestLRU_PutAndReport(t *testing.T) {
+	lru := createTestLru()
+	for i := 1; i <= testLruLimit; i++ {
+		evicted, key := lru.PutAndReport(i, fmt.Sprint("value", i))
+		assert.False(t, evicted, "no eviction is expected below the limit")
+		assert.Equal(t, 0, key)
+	}
+
+	evicted, key := lru.PutAndReport(1, "other value1")
+	assert.False(t, evicted, "no eviction is expected for an existing key")
+	assert.Equal(t, 0, key)
+
+	evicted, key = lru.PutAndReport(4, "value4")
+	assert.True(t, evicted)
+	assert.Equal(t, 2, key)
+	_, ok := lru.GetIfPresent(2)
+	assert.False(t, ok)
+	assert.Equal(t, testLruLimit, lru.Size())
+}
+
+func TestLRU_Validate(t *testing.T) {
+	lru := NewLRU[int, string](5)
+	assert.Nil(t, lru.Validate())
+	for i := 1; i <= 7; i++ {
+		lru.Put(i, fmt.Sprint("value", i))
+	}
+	lru.Get(4)
+	lru.Evict(6)
+	lru.PutIfAbsent(8, "value8")
+	assert.Nil(t, lru.Validate())
+	lru.Clear()
+	assert.Nil(t, lru.Validate())
+}
+
+func TestLRU_Validate_corrupted(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(lru *LRU[int, string])
+	}{
+		{name: "map entry", corrupt: func(lru *LRU[int, string]) { delete(lru.mp, 2) }},
+		{name: "dangling entity", corrupt: func(lru *LRU[int, string]) {
+			lru.mp[123] = &lruEntity[int, string]{key: 123, value: "value123"}
+		}},
+		{name: "head.prev", corrupt: func(lru *LRU[int, string]) { lru.entities.head.prev = lru.entities.tail }},
+		{name: "tail.next", corrupt: func(lru *LRU[int, string]) { lru.entities.tail.next = lru.entities.head }},
+		{name: "next.prev", corrupt: func(lru *LRU[int, string]) { lru.entities.head.next.prev = nil }},
+		{name: "tail", corrupt: func(lru *LRU[int, string]) { lru.entities.tail = lru.entities.head }},
+		{name: "no head", corrupt: func(lru *LRU[int, string]) { lru.entities.head = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lru := createTestLru()
+			lru.Put(1, "value1")
+			lru.Put(2, "value2")
+			lru.Put(3, "value3")
+			tt.corrupt(lru)
+			err := lru.Validate()
+			assert.NotNil(t, err)
+			t.Log(err)
+		})
+	}
+}
+
+func TestLRU_Clone(t *testing.T) {
+	lru := createTestLru()
+	lru.Put(1, "value1")
+	lru.Put(2, "value2")
+	lru.Put(3, "value3")
+	lru.Get(1)
+
+	clone := lru.Clone()
+
+	assert.Nil(t, clone.Validate())
+	assert.Equal(t, lru.Limit(), clone.Limit())
+	assert.Equal(t, lru.Values(), clone.Values())
+	clone.Put(4, "value4")
+	_, ok := clone.GetIfPresent(2)
+	assert.False(t, ok, "the clone must evict the same entry as the original at clone time")
+	assert.Equal(t, []string{"value4", "value1", "value3"}, clone.Values())
+	assert.Equal(t, []string{"value1", "value3", "value2"}, lru.Values(), "the original must not be changed")
+	lru.Put(1, "other value1")
+	val, _ := clone.GetIfPresent(1)
+	assert.Equal(t, "value1", val)
+}
+
+func TestLRU_PutIfRoom(t *testing.T) {
+	lru := NewLRU[int, string](testLruLimit)
+	for i := 1; i < testLruLimit; i++ {
+		assert.True(t, lru.PutIfRoom(i, fmt.Sprint("value ", i)))
+	}
+	assert.True(t, lru.PutIfRoom(testLruLimit, "last"))
+	assert.Equal(t, testLruLimit, lru.Size())
+
+	assert.False(t, lru.PutIfRoom(testLruLimit+1, "rejected"))
+	assert.Equal(t, testLruLimit, lru.Size())
+	ok, _ := lru.Get(testLruLimit + 1)
+	assert.False(t, ok)
+	ok, _ = lru.Get(1)
+	assert.True(t, ok)
+
+	assert.True(t, lru.PutIfRoom(2, "updated"))
+	assert.Equal(t, testLruLimit, lru.Size())
+	assert.Equal(t, []string{"updated", "value 1", "last"}, lru.Values())
+}
+
+func TestLRU_PutAllReport(t *testing.T) {
+	lru := createTestLru()
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	assert.Equal(t, []Entry[int, string]{{Key: 1, Value: "value 1"}}, lru.PutAllReport(map[int]string{10: "v10"}))
+
+	before := lru.Copy()
+	batch := map[int]string{100: "v100", 200: "v200", 300: "v300", 2: "updated"}
+	evicted := lru.PutAllReport(batch)
+	assert.Equal(t, testLruLimit, lru.Size())
+	for _, entry := range evicted {
+		_, ok := lru.GetIfPresent(entry.Key)
+		assert.False(t, ok, "evicted key is present:", entry.Key)
+	}
+	after := lru.Copy()
+	gone := make(map[int]string)
+	for key, value := range before {
+		if _, ok := after[key]; !ok {
+			gone[key] = value
+		}
+	}
+	for key, value := range batch {
+		if _, ok := after[key]; !ok {
+			gone[key] = value
+		}
+	}
+	reported := make(map[int]string, len(evicted))
+	for _, entry := range evicted {
+		reported[entry.Key] = entry.Value
+	}
+	assert.Equal(t, gone, reported)
+	assert.Empty(t, lru.PutAllReport(nil))
+}
+
+func TestLRU_PutAllReportOrdered(t *testing.T) {
+	lru := createTestLru()
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	evicted := lru.PutAllReportOrdered([]Entry[int, string]{{Key: 10, Value: "v10"}, {Key: 2, Value: "updated"},
+		{Key: 20, Value: "v20"}, {Key: 10, Value: "last v10"}, {Key: 30, Value: "v30"}})
+	expected := []Entry[int, string]{{Key: 1, Value: "value 1"}, {Key: 3, Value: "value 3"},
+		{Key: 2, Value: "updated"}}
+	assert.Equal(t, expected, evicted)
+	assert.Equal(t, []Entry[int, string]{{Key: 30, Value: "v30"}, {Key: 20, Value: "v20"},
+		{Key: 10, Value: "last v10"}}, lru.TopN(testLruLimit))
+}
+
+func TestLRU_ForEachReverse(t *testing.T) {
+	lru := createTestLru()
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	lru.Get(1)
+	forward := make([]Entry[int, string], 0, testLruLimit)
+	lru.ForEach(func(key int, value string) {
+		forward = append(forward, Entry[int, string]{Key: key, Value: value})
+	})
+	assert.Equal(t, []Entry[int, string]{{1, "value 1"}, {3, "value 3"}, {2, "value 2"}}, forward)
+	reverse := make([]Entry[int, string], 0, testLruLimit)
+	lru.ForEachReverse(func(key int, value string) {
+		reverse = append(reverse, Entry[int, string]{Key: key, Value: value})
+	})
+	slices.Reverse(reverse)
+	assert.Equal(t, forward, reverse)
+	assert.Equal(t, []string{"value 1", "value 3", "value 2"}, lru.Values())
+}
+
+func TestLRU_TopN(t *testing.T) {
+	lru := NewLRU[int, string](5)
+	for i := 1; i <= 5; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	lru.Get(2)
+	lru.Touch(4)
+	assert.Equal(t, []Entry[int, string]{{4, "value 4"}, {2, "value 2"}, {5, "value 5"}}, lru.TopN(3))
+	assert.Equal(t, []string{"value 4", "value 2", "value 5", "value 3", "value 1"}, lru.Values())
+	assert.Equal(t, 5, len(lru.TopN(10)))
+	assert.Empty(t, lru.TopN(0))
+	assert.Empty(t, lru.TopN(-1))
+	assert.Empty(t, createTestLru().TopN(3))
+}
+
+func TestLRU_Clear_unlinks_entities(t *testing.T) {
+	lru := createTestLru()
+	for i := 1; i <= testLruLimit; i++ {
+		lru.Put(i, fmt.Sprint("value ", i))
+	}
+	escaped := lru.entities.head
+	collected := make(chan struct{})
+	runtime.SetFinalizer(lru.entities.tail, func(_ *lruEntity[int, string]) { close(collected) })
+
+	lru.Clear()
+
+	assert.Equal(t, 0, lru.Size())
+	assert.Nil(t, escaped.prev)
+	assert.Nil(t, escaped.next)
+	assert.Equal(t, "", escaped.value)
+	deadline := time.After(5 * time.Second)
+	for finalized := false; !finalized; {
+		runtime.GC()
+		select {
+		case <-collected:
+			finalized = true
+		case <-deadline:
+			t.Fatal("the tail entity is still reachable after Clear()")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	runtime.KeepAlive(escaped)
+}
+
 func createTestLru() *LRU[int, string] {
 	return NewLRU[int, string](testLruLimit)
 }
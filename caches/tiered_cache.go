@@ -0,0 +1,101 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"github.com/PavloVM7/go-concurrency/collections"
+	"sync"
+	"sync/atomic"
+)
+
+// TieredCache is a two-tier read-through cache: an LRU cache is the hot tier
+// and a ConcurrentMap is the backing store.
+// The TieredCache is safe for concurrent use by multiple goroutines.
+// - K - comparable key type
+// - V - value type
+type TieredCache[K comparable, V any] struct {
+	// mu serializes the writes to both tiers, so the tiers never disagree
+	mu        sync.Mutex
+	hot       *LRU[K, V]
+	store     *collections.ConcurrentMap[K, V]
+	hotHits   atomic.Uint64
+	storeHits atomic.Uint64
+	misses    atomic.Uint64
+}
+
+// TieredCacheStats contains the statistics of the TieredCache lookups.
+type TieredCacheStats struct {
+	// HotHits is the number of values found in the LRU tier
+	HotHits uint64
+	// StoreHits is the number of values not found in the LRU tier, but found in the backing store
+	StoreHits uint64
+	// Misses is the number of values found in neither tier
+	Misses uint64
+}
+
+// Get returns the value to which the specified key is mapped and the sign of existence of this value.
+// The LRU tier is checked first, if the value is not found there, the backing store is checked,
+// and the found value is promoted into the LRU tier. Only the promotion takes the write lock,
+// the lookups that miss both tiers don't.
+// If a value for the key exists, its value is returned and true,
+// otherwise the default value for the value type is returned and false.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (tcache *TieredCache[K, V]) Get(key K) (bool, V) {
+	if ok, val := tcache.hot.Get(key); ok {
+		tcache.hotHits.Add(1)
+		return true, val
+	}
+	if _, ok := tcache.store.Get(key); !ok {
+		tcache.misses.Add(1)
+		var res V
+		return false, res
+	}
+	tcache.storeHits.Add(1)
+	tcache.mu.Lock()
+	defer tcache.mu.Unlock()
+	// the value is read again under the lock, so a concurrent Put can't be overwritten by a stale value
+	val, ok := tcache.store.Get(key)
+	if !ok {
+		return false, val
+	}
+	_, val = tcache.hot.PutIfAbsent(key, val)
+	return true, val
+} //revive:enable:confusing-naming
+
+// Put maps the specified key to the specified value in both tiers.
+// Both writes are done under the cache write lock, so concurrent puts to the same key
+// leave the same value in both tiers.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (tcache *TieredCache[K, V]) Put(key K, value V) {
+	tcache.mu.Lock()
+	tcache.store.Put(key, value)
+	tcache.hot.Put(key, value)
+	tcache.mu.Unlock()
+}
+
+// Stats returns the statistics of the lookups performed by the Get method.
+func (tcache *TieredCache[K, V]) Stats() TieredCacheStats {
+	return TieredCacheStats{
+		HotHits:   tcache.hotHits.Load(),
+		StoreHits: tcache.storeHits.Load(),
+		Misses:    tcache.misses.Load(),
+	}
+}
+
+// NewTieredCache creates and returns a new TieredCache.
+// Note! After the cache is created, the store must only be written through the cache (see Put),
+// otherwise the LRU tier may keep serving an old value of a promoted key until it is evicted.
+// The store can be read directly.
+// - limit - specifies the max number of key-value pairs that we want to keep in the LRU tier.
+// - store - the backing store, if it is nil, a new empty ConcurrentMap is used.
+func NewTieredCache[K comparable, V any](limit int, store *collections.ConcurrentMap[K, V]) *TieredCache[K, V] {
+	if store == nil {
+		store = collections.NewConcurrentMap[K, V]()
+	}
+	return &TieredCache[K, V]{hot: NewLRU[K, V](limit), store: store}
+}
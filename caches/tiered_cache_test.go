@@ -0,0 +1,107 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"fmt"
+	"github.com/PavloVM7/go-concurrency/collections"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTieredCache_Get(t *testing.T) {
+	tcache := NewTieredCache[int, string](testLruLimit, nil)
+	tcache.Put(1, "one")
+	ok, actual := tcache.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", actual)
+	ok, _ = tcache.Get(2)
+	assert.False(t, ok)
+	assert.Equal(t, TieredCacheStats{HotHits: 1, Misses: 1}, tcache.Stats())
+}
+
+func TestTieredCache_Get_promotes(t *testing.T) {
+	store := collections.NewConcurrentMap[int, string]()
+	store.Put(100, "stored")
+	tcache := NewTieredCache[int, string](testLruLimit, store)
+
+	ok, actual := tcache.Get(100)
+	assert.True(t, ok)
+	assert.Equal(t, "stored", actual)
+	assert.Equal(t, TieredCacheStats{StoreHits: 1}, tcache.Stats())
+
+	ok, actual = tcache.Get(100)
+	assert.True(t, ok)
+	assert.Equal(t, "stored", actual)
+	assert.Equal(t, TieredCacheStats{HotHits: 1, StoreHits: 1}, tcache.Stats(), "the entry should be promoted")
+}
+
+func TestTieredCache_Put(t *testing.T) {
+	store := collections.NewConcurrentMap[int, string]()
+	tcache := NewTieredCache[int, string](testLruLimit, store)
+	for i := 0; i < testLruLimit*2; i++ {
+		tcache.Put(i, fmt.Sprint("value ", i))
+	}
+	assert.Equal(t, testLruLimit*2, store.Size(), "the backing store keeps the values evicted from the LRU tier")
+
+	ok, actual := tcache.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, "value 0", actual)
+	ok, _ = tcache.Get(testLruLimit*2 - 1)
+	assert.True(t, ok)
+	assert.Equal(t, TieredCacheStats{HotHits: 1, StoreHits: 1}, tcache.Stats())
+}
+
+func TestTieredCache_Put_concurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 10_000
+		keys       = testLruLimit * 2
+	)
+	tcache := NewTieredCache[int, int](testLruLimit, nil)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				key := i % keys
+				if i%5 == 0 {
+					tcache.Get(key)
+				} else {
+					tcache.Put(key, g*iterations+i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for key := 0; key < keys; key++ {
+		stored, ok := tcache.store.Get(key)
+		assert.True(t, ok, "key: %d", key)
+		if hot, ok := tcache.hot.GetIfPresent(key); ok {
+			assert.Equal(t, stored, hot, "the tiers disagree, key: %d", key)
+		}
+	}
+}
+
+func TestTieredCache_Get_missWithoutLock(t *testing.T) {
+	tcache := NewTieredCache[int, string](testLruLimit, nil)
+	tcache.mu.Lock()
+	defer tcache.mu.Unlock()
+	result := make(chan bool)
+	go func() {
+		ok, _ := tcache.Get(1)
+		result <- ok
+	}()
+	select {
+	case ok := <-result:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("a lookup that misses both tiers must not take the write lock")
+	}
+	assert.Equal(t, TieredCacheStats{Misses: 1}, tcache.Stats())
+}
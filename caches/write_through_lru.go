@@ -0,0 +1,91 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+// WriteThroughLRU is an LRU cache that persists every inserted or updated value
+// to a backing store using a writer function before caching it.
+// If the writer fails, the cache is not changed.
+// Evictions don't call the writer, so the backing store keeps the evicted values.
+// The WriteThroughLRU is safe for concurrent use by multiple goroutines.
+//   - K - comparable key type
+//   - V - value type
+type WriteThroughLRU[K comparable, V any] struct {
+	lru    *LRU[K, V]
+	writer func(key K, value V) error
+}
+
+// Put persists the specified value using the writer and maps the specified key to it.
+// Returns the writer's error, in which case the cache is not changed.
+// The writer is called under the cache lock, so the writes are persisted in the same order
+// in which they are applied to the cache.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (wlru *WriteThroughLRU[K, V]) Put(key K, value V) error {
+	lru := wlru.lru
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	if err := wlru.writer(key, value); err != nil {
+		return err
+	}
+	if entity, ok := lru.mp[key]; ok {
+		entity.value = value
+		lru.entities.moveToHead(entity)
+	} else {
+		lru.putEntity(&lruEntity[K, V]{key: key, value: value})
+	}
+	return nil
+}
+
+// PutIfAbsent persists the specified value using the writer and maps the specified key to it
+// if the key doesn't exist, returns true and a new value.
+// If the key exists, the writer is not called, the method returns false and the previous key value.
+// If the writer fails, the method returns its error and the cache is not changed.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (wlru *WriteThroughLRU[K, V]) PutIfAbsent(key K, value V) (bool, V, error) {
+	lru := wlru.lru
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	if entity, ok := lru.mp[key]; ok {
+		return false, entity.value, nil
+	}
+	if err := wlru.writer(key, value); err != nil {
+		var res V
+		return false, res, err
+	}
+	lru.putEntity(&lruEntity[K, V]{key: key, value: value})
+	return true, value, nil
+}
+
+// Get returns the value to which the specified key is mapped and the sign of existence of this value.
+// The key is marked as the most recently used one.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (wlru *WriteThroughLRU[K, V]) Get(key K) (bool, V) {
+	return wlru.lru.Get(key)
+} //revive:enable:confusing-naming
+
+// Evict evicts the value to which the specified key is mapped, the writer is not called.
+//   - key - the key that needs to be removed
+//
+//revive:disable:confusing-naming
+func (wlru *WriteThroughLRU[K, V]) Evict(key K) (bool, V) {
+	return wlru.lru.Evict(key)
+} //revive:enable:confusing-naming
+
+// Size returns the number of cached values
+//
+//revive:disable:confusing-naming
+func (wlru *WriteThroughLRU[K, V]) Size() int {
+	return wlru.lru.Size()
+} //revive:enable:confusing-naming
+
+// NewWriteThroughLRU creates and returns a new write-through LRU cache.
+//   - limit - specifies the max number of key-value pairs that we want to keep
+//   - writer - the function that persists a value to the backing store
+func NewWriteThroughLRU[K comparable, V any](limit int, writer func(key K, value V) error) *WriteThroughLRU[K, V] {
+	return &WriteThroughLRU[K, V]{lru: NewLRU[K, V](limit), writer: writer}
+}
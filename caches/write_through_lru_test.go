@@ -0,0 +1,75 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package caches
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errTestWrite = errors.New("test write error")
+
+func TestWriteThroughLRU_Put(t *testing.T) {
+	store := make(map[int]string)
+	wlru := NewWriteThroughLRU[int, string](testLruLimit, func(key int, value string) error {
+		store[key] = value
+		return nil
+	})
+	assert.Nil(t, wlru.Put(1, "one"))
+	assert.Nil(t, wlru.Put(1, "uno"))
+	ok, actual := wlru.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "uno", actual)
+	assert.Equal(t, map[int]string{1: "uno"}, store)
+
+	added, actual, err := wlru.PutIfAbsent(2, "two")
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "two", actual)
+	added, actual, err = wlru.PutIfAbsent(2, "dos")
+	assert.Nil(t, err)
+	assert.False(t, added)
+	assert.Equal(t, "two", actual)
+	assert.Equal(t, map[int]string{1: "uno", 2: "two"}, store)
+}
+
+func TestWriteThroughLRU_Put_fail(t *testing.T) {
+	wlru := NewWriteThroughLRU[int, string](testLruLimit, func(key int, value string) error {
+		if value == "bad" {
+			return errTestWrite
+		}
+		return nil
+	})
+	assert.Nil(t, wlru.Put(1, "one"))
+	assert.ErrorIs(t, wlru.Put(1, "bad"), errTestWrite)
+	ok, actual := wlru.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", actual)
+
+	assert.ErrorIs(t, wlru.Put(2, "bad"), errTestWrite)
+	added, _, err := wlru.PutIfAbsent(3, "bad")
+	assert.ErrorIs(t, err, errTestWrite)
+	assert.False(t, added)
+	assert.Equal(t, 1, wlru.Size())
+	ok, _ = wlru.Get(2)
+	assert.False(t, ok)
+}
+
+func TestWriteThroughLRU_eviction(t *testing.T) {
+	writes := 0
+	wlru := NewWriteThroughLRU[int, string](testLruLimit, func(key int, value string) error {
+		writes++
+		return nil
+	})
+	for i := 0; i < testLruLimit*2; i++ {
+		assert.Nil(t, wlru.Put(i, "value"))
+	}
+	assert.Equal(t, testLruLimit*2, writes)
+	assert.Equal(t, testLruLimit, wlru.Size())
+	ok, _ := wlru.Evict(testLruLimit*2 - 1)
+	assert.True(t, ok)
+	assert.Equal(t, testLruLimit*2, writes)
+}
@@ -0,0 +1,27 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+// Collection is the common interface of the thread safe collections of this package.
+//   - T - value type
+type Collection[T any] interface {
+	// Size returns the number of elements in the collection.
+	Size() int
+	// IsEmpty returns true if the collection does not contain any elements.
+	IsEmpty() bool
+	// Clear removes all elements from the collection.
+	Clear()
+	// ToSlice returns a slice containing all elements of the collection.
+	ToSlice() []T
+	// ForEach performs a given action for each element of the collection.
+	ForEach(f func(value T))
+}
+
+var (
+	_ Collection[int] = (*ConcurrentSet[int])(nil)
+	_ Collection[int] = (*ConcurrentLinkedList[int])(nil)
+	_ Collection[int] = (*ConcurrentOrderedSet[int])(nil)
+	_ Collection[int] = (*ConcurrentArrayList[int])(nil)
+)
@@ -0,0 +1,43 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"testing"
+)
+
+func TestCollection(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection Collection[int]
+	}{
+		{name: "ConcurrentSet", collection: NewConcurrentSetWithValues[int](1, 2, 3)},
+		{name: "ConcurrentLinkedList", collection: NewConcurrentLinkedListItems[int](1, 2, 3)},
+		{name: "ConcurrentOrderedSet", collection: NewConcurrentOrderedSet[int](1, 2, 3)},
+		{name: "ConcurrentArrayList", collection: NewConcurrentArrayListItems[int](1, 2, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, 3, tt.collection.Size())
+			assert.False(t, tt.collection.IsEmpty())
+			sum := 0
+			tt.collection.ForEach(func(value int) {
+				sum += value
+			})
+			assert.Equal(t, 6, sum)
+			actual := tt.collection.ToSlice()
+			slices.Sort(actual)
+			assert.Equal(t, []int{1, 2, 3}, actual)
+
+			tt.collection.Clear()
+
+			assert.Equal(t, 0, tt.collection.Size())
+			assert.True(t, tt.collection.IsEmpty())
+			assert.Empty(t, tt.collection.ToSlice())
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import "sync"
+
+// ConcurrentArrayList is a thread safe list backed by a slice.
+// Unlike ConcurrentLinkedList, it provides access to an element by its index in O(1),
+// but the removal of an element shifts all subsequent elements, so it takes O(n).
+// ConcurrentArrayList is safe for concurrent use by multiple goroutines.
+//   - T - value type
+type ConcurrentArrayList[T any] struct {
+	mu    sync.RWMutex
+	items []T
+}
+
+// Add appends specified element to the end of this list.
+//   - value - the value to be appended
+func (alist *ConcurrentArrayList[T]) Add(value T) {
+	alist.mu.Lock()
+	alist.items = append(alist.items, value)
+	alist.mu.Unlock()
+}
+
+// Get returns an item at the specified position in this list
+// or the zero value of type T and an error if the index is out of range.
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) Get(index int) (T, error) {
+	alist.mu.RLock()
+	defer alist.mu.RUnlock()
+	if index < 0 || index >= len(alist.items) {
+		var res T
+		return res, ErrIndexOutOfRange
+	}
+	return alist.items[index], nil
+} //revive:enable:confusing-naming
+
+// Set replaces the element at the specified position in this list with the specified value.
+// Returns an error if the index is out of range.
+//   - index - the index of the element to be replaced
+//   - value - the value to be stored at the specified position
+func (alist *ConcurrentArrayList[T]) Set(index int, value T) error {
+	alist.mu.Lock()
+	defer alist.mu.Unlock()
+	if index < 0 || index >= len(alist.items) {
+		return ErrIndexOutOfRange
+	}
+	alist.items[index] = value
+	return nil
+}
+
+// Remove removes the element at the specified position in this list and returns its value
+// or a default value (zero value) of type T and an error if the index is out of range.
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) Remove(index int) (T, error) {
+	alist.mu.Lock()
+	defer alist.mu.Unlock()
+	var res T
+	if index < 0 || index >= len(alist.items) {
+		return res, ErrIndexOutOfRange
+	}
+	res = alist.items[index]
+	last := len(alist.items) - 1
+	copy(alist.items[index:], alist.items[index+1:])
+	var zero T
+	alist.items[last] = zero
+	alist.items = alist.items[:last]
+	return res, nil
+} //revive:enable:confusing-naming
+
+// ToArray returns an array containing all elements of this list in the proper sequence
+// (from the first to the last element).
+func (alist *ConcurrentArrayList[T]) ToArray() []T {
+	alist.mu.RLock()
+	result := make([]T, len(alist.items))
+	copy(result, alist.items)
+	alist.mu.RUnlock()
+	return result
+}
+
+// ToSlice returns a slice containing all elements of this list in the proper sequence
+// (from the first to the last element). It is the same as ToArray.
+func (alist *ConcurrentArrayList[T]) ToSlice() []T {
+	return alist.ToArray()
+}
+
+// ForEach performs a given action for each element of this list in the proper sequence
+// (from the first to the last element).
+//   - f - the function, that will be called for each value in ConcurrentArrayList
+//
+// It should not be used to modify values if the value type (T) is a reference type,
+// because a read lock is used under the hood.
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) ForEach(f func(value T)) {
+	alist.mu.RLock()
+	for _, value := range alist.items {
+		f(value)
+	}
+	alist.mu.RUnlock()
+} //revive:enable:confusing-naming
+
+// Clear clears this list
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) Clear() {
+	alist.mu.Lock()
+	alist.items = nil
+	alist.mu.Unlock()
+} //revive:enable:confusing-naming
+
+// Size returns the number of elements in this list
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) Size() int {
+	alist.mu.RLock()
+	defer alist.mu.RUnlock()
+	return len(alist.items)
+} //revive:enable:confusing-naming
+
+// IsEmpty returns true if this list does not contain any elements
+//
+//revive:disable:confusing-naming
+func (alist *ConcurrentArrayList[T]) IsEmpty() bool {
+	alist.mu.RLock()
+	defer alist.mu.RUnlock()
+	return len(alist.items) == 0
+} //revive:enable:confusing-naming
+
+// NewConcurrentArrayList constructs an empty list
+func NewConcurrentArrayList[T any]() *ConcurrentArrayList[T] {
+	return &ConcurrentArrayList[T]{}
+}
+
+// NewConcurrentArrayListCapacity constructs an empty list with an initial space size (capacity)
+//   - capacity - initial space size
+func NewConcurrentArrayListCapacity[T any](capacity int) *ConcurrentArrayList[T] {
+	return &ConcurrentArrayList[T]{items: make([]T, 0, capacity)}
+}
+
+// NewConcurrentArrayListItems constructs a list containing the specified elements
+func NewConcurrentArrayListItems[T any](values ...T) *ConcurrentArrayList[T] {
+	items := make([]T, len(values))
+	copy(items, values)
+	return &ConcurrentArrayList[T]{items: items}
+}
@@ -0,0 +1,38 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkConcurrentArrayList_Get(b *testing.B) {
+	const count = 10_000
+	values := make([]int, count)
+	for i := range values {
+		values[i] = i
+	}
+	benchmarks := []struct {
+		name string
+		get  func(index int) (int, error)
+	}{
+		{name: "ConcurrentLinkedList", get: NewConcurrentLinkedListItems[int](values...).Get},
+		{name: "ConcurrentArrayList", get: NewConcurrentArrayListItems[int](values...).Get},
+	}
+	for _, bm := range benchmarks {
+		bmv := bm
+		b.Run(bmv.name, func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				index := r.Intn(count)
+				if value, err := bmv.get(index); err != nil || value != index {
+					b.Fatal("unexpected value:", value, "error:", err)
+				}
+			}
+		})
+	}
+}
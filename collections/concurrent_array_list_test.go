@@ -0,0 +1,79 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConcurrentArrayList_Get(t *testing.T) {
+	crt := func(num int) string {
+		return fmt.Sprint("list item ", num)
+	}
+	list := NewConcurrentArrayList[string]()
+	for i := 1; i <= 5; i++ {
+		list.Add(crt(i))
+	}
+	assert.Equal(t, 5, list.Size(), "incorrect list size")
+	for i := 0; i < list.Size(); i++ {
+		actual, err := list.Get(i)
+		assert.Nil(t, err, "unexpected error:", err)
+		assert.Equal(t, crt(i+1), actual, "index:", i)
+	}
+}
+
+func TestConcurrentArrayList_Get_fail(t *testing.T) {
+	list := NewConcurrentArrayListItems[string]("value")
+	val, err := list.Get(-1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange, "unexpected error")
+	assert.Equal(t, "", val, "incorrect default value")
+	val, err = list.Get(1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange, "unexpected error")
+	assert.Equal(t, "", val, "incorrect default value")
+}
+
+func TestConcurrentArrayList_Set(t *testing.T) {
+	list := NewConcurrentArrayListItems[int](1, 2, 3)
+	assert.Nil(t, list.Set(1, 20))
+	assert.ErrorIs(t, list.Set(3, 40), ErrIndexOutOfRange)
+	assert.Equal(t, []int{1, 20, 3}, list.ToArray())
+}
+
+func TestConcurrentArrayList_Remove(t *testing.T) {
+	list := NewConcurrentArrayListCapacity[int](4)
+	for i := 1; i <= 4; i++ {
+		list.Add(i)
+	}
+	got3, err := list.Remove(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, got3)
+	assert.Equal(t, []int{1, 2, 4}, list.ToArray())
+
+	got1, _ := list.Remove(0)
+	assert.Equal(t, 1, got1)
+	assert.Equal(t, []int{2, 4}, list.ToArray())
+
+	got4, _ := list.Remove(1)
+	assert.Equal(t, 4, got4)
+	assert.Equal(t, []int{2}, list.ToArray())
+
+	got2, _ := list.Remove(0)
+	assert.Equal(t, 2, got2)
+	assert.Equal(t, 0, len(list.ToArray()))
+	assert.True(t, list.IsEmpty())
+}
+
+func TestConcurrentArrayList_Remove_fail(t *testing.T) {
+	list := NewConcurrentArrayList[string]()
+	actual, err := list.Remove(0)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange, "expected an 'index is out of range' error")
+	assert.Equal(t, "", actual)
+	list.Add("value")
+	actual, err = list.Remove(1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange, "expected an 'index is out of range' error")
+	assert.Equal(t, "", actual)
+}
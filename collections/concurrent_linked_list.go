@@ -6,6 +6,9 @@ package collections
 
 import (
 	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
 	"sync"
 )
 
@@ -16,16 +19,76 @@ var (
 
 // ConcurrentLinkedList is a thread safe implementation of a double-linked list
 type ConcurrentLinkedList[T any] struct {
-	mu    sync.RWMutex
-	first *listItem[T]
-	last  *listItem[T]
-	size  int
+	mu        sync.RWMutex
+	first     *listItem[T]
+	last      *listItem[T]
+	size      int
+	threshold *sizeThreshold
+	// free is a singly linked (by the next field) list of preallocated nodes that are not used yet
+	free *listItem[T]
+}
+
+// newItem returns a preallocated node with the specified value if there is one, otherwise allocates a new node.
+// It must be called under the write lock.
+func (clist *ConcurrentLinkedList[T]) newItem(value T) *listItem[T] {
+	item := clist.free
+	if item == nil {
+		return &listItem[T]{value: value}
+	}
+	clist.free = item.next
+	item.next = nil
+	item.value = value
+	return item
+}
+
+type sizeThreshold struct {
+	high   int
+	low    int
+	onHigh func()
+	onLow  func()
+	above  bool
+}
+
+// OnSizeThreshold sets the functions that are called when the size of this list crosses the specified watermarks.
+// The onHigh function is called when the size becomes greater than high,
+// after that the onLow function is called when the size becomes less than low, and so on (hysteresis).
+// So, the functions are not called repeatedly while the size stays between the watermarks.
+// The functions are called after the modification that caused the crossing, outside the lock,
+// so ConcurrentLinkedList methods can be used inside them.
+//   - high - the high watermark
+//   - low - the low watermark, it should be less than or equal to high
+//   - onHigh - the function that is called when the size rises above the high watermark, it can be nil
+//   - onLow - the function that is called when the size drops below the low watermark, it can be nil
+func (clist *ConcurrentLinkedList[T]) OnSizeThreshold(high, low int, onHigh, onLow func()) {
+	clist.mu.Lock()
+	clist.threshold = &sizeThreshold{high: high, low: low, onHigh: onHigh, onLow: onLow, above: clist.size > high}
+	clist.mu.Unlock()
+}
+
+// unlock releases the write lock and calls the threshold function if the size has crossed a watermark.
+func (clist *ConcurrentLinkedList[T]) unlock() {
+	var callback func()
+	if th := clist.threshold; th != nil {
+		if !th.above && clist.size > th.high {
+			th.above = true
+			callback = th.onHigh
+		} else if th.above && clist.size < th.low {
+			th.above = false
+			callback = th.onLow
+		}
+	}
+	clist.mu.Unlock()
+	if callback != nil {
+		callback()
+	}
 }
 
 // RemoveFirst removes the first item from this list and returns its value and true if it exists.
 // If the list is empty, a default value (zero value) of type T and false is returned.
 func (clist *ConcurrentLinkedList[T]) RemoveFirst() (T, bool) {
 	var res T
+	clist.mu.Lock()
+	defer clist.unlock()
 	if clist.first != nil {
 		res = clist.removeItem(clist.first)
 		return res, true
@@ -33,12 +96,24 @@ func (clist *ConcurrentLinkedList[T]) RemoveFirst() (T, bool) {
 	return res, false
 }
 
+// RemoveFirstAndSize does the same thing as RemoveFirst, but also returns the size of the list after the removal.
+func (clist *ConcurrentLinkedList[T]) RemoveFirstAndSize() (T, bool, int) {
+	var res T
+	clist.mu.Lock()
+	defer clist.unlock()
+	if clist.first != nil {
+		res = clist.removeItem(clist.first)
+		return res, true, clist.size
+	}
+	return res, false, clist.size
+}
+
 // RemoveLast removes the last item from this list and returns its value and true if it exists.
 // If the list is empty, a default value of type T (zero value) and false is returned.
 func (clist *ConcurrentLinkedList[T]) RemoveLast() (T, bool) {
 	var res T
 	clist.mu.Lock()
-	defer clist.mu.Unlock()
+	defer clist.unlock()
 	if clist.last != nil {
 		res = clist.removeItem(clist.last)
 		return res, true
@@ -57,7 +132,7 @@ func (clist *ConcurrentLinkedList[T]) Remove(index int) (T, error) {
 	if err == nil {
 		res = clist.removeItem(item)
 	}
-	clist.mu.Unlock()
+	clist.unlock()
 	return res, err
 } //revive:enable:confusing-naming
 func (clist *ConcurrentLinkedList[T]) removeItem(item *listItem[T]) T {
@@ -79,7 +154,7 @@ func (clist *ConcurrentLinkedList[T]) removeItem(item *listItem[T]) T {
 //   - needToRemove - a function that is applied to each element to determine if it should be deleted
 func (clist *ConcurrentLinkedList[T]) RemoveLastOccurrence(needToRemove func(value T) bool) (T, int) {
 	clist.mu.Lock()
-	defer clist.mu.Unlock()
+	defer clist.unlock()
 	index := clist.size
 	item := clist.last
 	for item != nil {
@@ -100,7 +175,7 @@ func (clist *ConcurrentLinkedList[T]) RemoveLastOccurrence(needToRemove func(val
 func (clist *ConcurrentLinkedList[T]) RemoveFirstOccurrence(needToRemove func(value T) bool) (T, int) {
 	index := -1
 	clist.mu.Lock()
-	defer clist.mu.Unlock()
+	defer clist.unlock()
 	item := clist.first
 	for item != nil {
 		index++
@@ -113,6 +188,20 @@ func (clist *ConcurrentLinkedList[T]) RemoveFirstOccurrence(needToRemove func(va
 	return res, -1
 }
 
+// ContainsFunc returns true if this list contains an element that satisfies the condition
+// specified by the function. The traversal stops at the first such element.
+//   - eq - a function that is applied to each element to determine if it is the one being searched for
+func (clist *ConcurrentLinkedList[T]) ContainsFunc(eq func(value T) bool) bool {
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	for item := clist.first; item != nil; item = item.next {
+		if eq(item.value) {
+			return true
+		}
+	}
+	return false
+}
+
 // RemoveAll removes from the list all elements that satisfy the condition specified by the needToRemove function.
 // Returns the number of elements removed
 //   - needToRemove - a function that is applied to each element to determine if it should be deleted
@@ -127,15 +216,73 @@ func (clist *ConcurrentLinkedList[T]) RemoveAll(needRemove func(value T) bool) i
 		}
 		item = item.next
 	}
-	clist.mu.Unlock()
+	clist.unlock()
 	return result
 }
 
+// DrainTo removes up to len(dst) elements from the beginning of this list and puts them into dst
+// in the proper sequence. Returns the number of transferred elements.
+//   - dst - the slice into which the elements are to be transferred
+func (clist *ConcurrentLinkedList[T]) DrainTo(dst []T) int {
+	clist.mu.Lock()
+	n := 0
+	for ; n < len(dst) && clist.first != nil; n++ {
+		dst[n] = clist.removeItem(clist.first)
+	}
+	clist.unlock()
+	return n
+}
+
+// DrainChannel starts a goroutine that repeatedly removes up to batchSize elements from the beginning
+// of this list and sends them as a batch to the returned channel until the list is empty,
+// then the channel is closed. Each batch is removed under a single write lock.
+// The channel is unbuffered, and the goroutine exits only when the list is empty,
+// so the channel must be read until it is closed, use DrainChannelDone to be able to stop reading earlier.
+// Note! A batch waiting to be sent is already removed from this list, so Size, ToArray, etc. don't see it.
+//   - batchSize - the max number of elements in a batch, a value less than 1 is treated as 1
+func (clist *ConcurrentLinkedList[T]) DrainChannel(batchSize int) <-chan []T {
+	return clist.DrainChannelDone(nil, batchSize)
+}
+
+// DrainChannelDone does the same thing as DrainChannel, but also stops when the done channel is closed.
+// If the done channel is closed before a batch is received, the batch is put back to the beginning
+// of this list in its original order, the goroutine exits and the returned channel is closed.
+// A nil done channel is never closed, so the goroutine runs until the returned channel is read to the end,
+// as with DrainChannel.
+// Note! A batch waiting to be sent is already removed from this list, so Size, ToArray, etc. don't see it
+// until it is put back.
+//   - done - the channel that is closed when the consumer no longer reads the batches
+//   - batchSize - the max number of elements in a batch, a value less than 1 is treated as 1
+func (clist *ConcurrentLinkedList[T]) DrainChannelDone(done <-chan struct{}, batchSize int) <-chan []T {
+	batchSize = max(batchSize, 1)
+	ch := make(chan []T)
+	go func() {
+		defer close(ch)
+		for {
+			batch := make([]T, batchSize)
+			n := clist.DrainTo(batch)
+			if n == 0 {
+				return
+			}
+			select {
+			case ch <- batch[:n]:
+			case <-done:
+				clist.addFirstAll(batch[:n])
+				return
+			}
+		}
+	}()
+	return ch
+}
+
 // AddFirst inserts specified element to the beginning this list.
 //   - value - the value to be inserted
 func (clist *ConcurrentLinkedList[T]) AddFirst(value T) {
-	item := &listItem[T]{value: value}
 	clist.mu.Lock()
+	clist.addFirstInner(clist.newItem(value))
+	clist.unlock()
+}
+func (clist *ConcurrentLinkedList[T]) addFirstInner(item *listItem[T]) {
 	if clist.first != nil {
 		clist.first.insert(item)
 	} else {
@@ -143,16 +290,24 @@ func (clist *ConcurrentLinkedList[T]) AddFirst(value T) {
 	}
 	clist.first = item
 	clist.size++
-	clist.mu.Unlock()
+}
+
+// addFirstAll inserts the values to the beginning of this list under a single write lock,
+// keeping their order.
+func (clist *ConcurrentLinkedList[T]) addFirstAll(values []T) {
+	clist.mu.Lock()
+	for i := len(values) - 1; i >= 0; i-- {
+		clist.addFirstInner(clist.newItem(values[i]))
+	}
+	clist.unlock()
 }
 
 // AddLast appends specified element to the end of this list.
 //   - value - the value to be appended
 func (clist *ConcurrentLinkedList[T]) AddLast(value T) {
-	item := &listItem[T]{value: value}
 	clist.mu.Lock()
-	clist.addLastInner(item)
-	clist.mu.Unlock()
+	clist.addLastInner(clist.newItem(value))
+	clist.unlock()
 }
 func (clist *ConcurrentLinkedList[T]) addLastInner(item *listItem[T]) {
 	if clist.last != nil {
@@ -164,6 +319,18 @@ func (clist *ConcurrentLinkedList[T]) addLastInner(item *listItem[T]) {
 	clist.size++
 }
 
+// AppendList appends all elements of the other list to the end of this list in the proper sequence.
+// The other list is not changed.
+//   - other - the list whose elements are to be appended
+func (clist *ConcurrentLinkedList[T]) AppendList(other *ConcurrentLinkedList[T]) {
+	values := other.ToArray()
+	clist.mu.Lock()
+	for _, value := range values {
+		clist.addLastInner(clist.newItem(value))
+	}
+	clist.unlock()
+}
+
 // GetFirst returns the first element of this list and true if it exists.
 // If the list is empty, this method returns the zero value of type T and false
 func (clist *ConcurrentLinkedList[T]) GetFirst() (T, bool) {
@@ -202,15 +369,83 @@ func (clist *ConcurrentLinkedList[T]) Get(index int) (T, error) {
 	}
 	return item.value, nil
 } //revive:enable:confusing-naming
+
+// UpdateAt replaces the element at the specified position in this list with the result of the function f
+// applied to the current element. Returns the new value or the zero value of type T
+// and an error if the index is out of range.
+//   - index - the index of the element to be updated
+//   - f - the function that returns a new value for the element
+func (clist *ConcurrentLinkedList[T]) UpdateAt(index int, f func(old T) T) (T, error) {
+	clist.mu.Lock()
+	defer clist.unlock()
+	item, err := clist.getByIndex(index)
+	if err != nil {
+		var res T
+		return res, err
+	}
+	item.value = f(item.value)
+	return item.value, nil
+}
+
+// Move relocates the element at the from position to the to position in this list,
+// the elements between them are shifted by one position. The element node is re-linked, its value is not copied.
+// Returns an error if any of the indexes is out of range.
+//   - from - the current index of the element
+//   - to - the index of the element after the call
+func (clist *ConcurrentLinkedList[T]) Move(from, to int) error {
+	clist.mu.Lock()
+	defer clist.unlock()
+	if to < 0 || to >= clist.size {
+		return ErrIndexOutOfRange
+	}
+	item, err := clist.getByIndex(from)
+	if err != nil || from == to {
+		return err
+	}
+	clist.removeItem(item)
+	if to == clist.size {
+		clist.addLastInner(item)
+		return nil
+	}
+	target, _ := clist.getByIndex(to)
+	target.insert(item)
+	if clist.first == target {
+		clist.first = item
+	}
+	clist.size++
+	return nil
+}
+
 func (clist *ConcurrentLinkedList[T]) getByIndex(index int) (*listItem[T], error) {
-	if index >= 0 && index < clist.size {
-		for i, item := 0, clist.first; item != nil; i, item = i+1, item.next {
-			if i == index {
-				return item, nil
-			}
+	if index < 0 || index >= clist.size {
+		return nil, ErrIndexOutOfRange
+	}
+	if index < clist.size/2 {
+		item := clist.first
+		for i := 0; i < index; i++ {
+			item = item.next
 		}
+		return item, nil
+	}
+	item := clist.last
+	for i := clist.size - 1; i > index; i-- {
+		item = item.prev
+	}
+	return item, nil
+}
+
+// Random returns a uniformly chosen random element of this list and true.
+// If the list is empty, this method returns the zero value of type T and false.
+//   - r - the source of random numbers
+func (clist *ConcurrentLinkedList[T]) Random(r *rand.Rand) (T, bool) {
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	if clist.size == 0 {
+		var res T
+		return res, false
 	}
-	return nil, ErrIndexOutOfRange
+	item, _ := clist.getByIndex(r.Intn(clist.size))
+	return item.value, true
 }
 
 // ToArray returns an array containing all elements of this list in the proper sequence
@@ -225,16 +460,118 @@ func (clist *ConcurrentLinkedList[T]) ToArray() []T {
 	return result
 }
 
-// Clear clears this list
+// ToArrayInto does the same thing as ToArray, but fills the specified slice instead of allocating a new one,
+// the slice is grown only if its capacity is less than the size of this list.
+// Returns the filled slice, its length equals Size(). It allows reusing a buffer across calls.
+//   - dst - the slice to be filled
+func (clist *ConcurrentLinkedList[T]) ToArrayInto(dst []T) []T {
+	clist.mu.RLock()
+	dst = slices.Grow(dst[:0], clist.size)[:clist.size]
+	for i, item := 0, clist.first; item != nil; i, item = i+1, item.next {
+		dst[i] = item.value
+	}
+	clist.mu.RUnlock()
+	return dst
+}
+
+// ToSlice returns a slice containing all elements of this list in the proper sequence
+// (from the first to the last element). It is the same as ToArray.
+func (clist *ConcurrentLinkedList[T]) ToSlice() []T {
+	return clist.ToArray()
+}
+
+// ForEach performs a given action for each element of this list in the proper sequence
+// (from the first to the last element).
+//   - f - the function, that will be called for each value in ConcurrentLinkedList
+//
+// It should not be used to modify values if the value type (T) is a reference type,
+// because a read lock is used under the hood.
+//
+//revive:disable:confusing-naming
+func (clist *ConcurrentLinkedList[T]) ForEach(f func(value T)) {
+	clist.mu.RLock()
+	for item := clist.first; item != nil; item = item.next {
+		f(item.value)
+	}
+	clist.mu.RUnlock()
+} //revive:enable:confusing-naming
+
+// ForEachRead performs a given action for each element of this list in the proper sequence
+// (from the first to the last element).
+//   - f - the function, that will be called for each element with its index and value
+//
+// A read lock is used under the hood, so several readers can iterate the list at the same time.
+// Note! ConcurrentLinkedList methods, such as Get, Size and GetFirst can be used inside the 'f' function.
+// However, you should not use methods that modify ConcurrentLinkedList, as this will cause a deadlock.
+func (clist *ConcurrentLinkedList[T]) ForEachRead(f func(index int, value T)) {
+	clist.mu.RLock()
+	for i, item := 0, clist.first; item != nil; i, item = i+1, item.next {
+		f(i, item.value)
+	}
+	clist.mu.RUnlock()
+}
+
+// Clear clears this list.
+// The links between the removed elements are broken, so each of them can be collected by the garbage collector
+// independently of the others. Therefore, the complexity of this method is O(n).
 //
 //revive:disable:confusing-naming
 func (clist *ConcurrentLinkedList[T]) Clear() {
 	clist.mu.Lock()
+	clist.clearInner()
+	clist.unlock()
+} //revive:enable:confusing-naming
+func (clist *ConcurrentLinkedList[T]) clearInner() {
+	var zero T
+	for item := clist.first; item != nil; {
+		next := item.next
+		item.prev = nil
+		item.next = nil
+		item.value = zero
+		item = next
+	}
 	clist.first = nil
 	clist.last = nil
 	clist.size = 0
-	clist.mu.Unlock()
-} //revive:enable:confusing-naming
+}
+
+// ReplaceAllContents atomically replaces all the elements of this list with the specified values.
+// Since the whole replacement is performed under the write lock,
+// readers see either the previous or the new contents of the list, but never a mix of them.
+//   - values - the new elements of the list
+func (clist *ConcurrentLinkedList[T]) ReplaceAllContents(values ...T) {
+	clist.mu.Lock()
+	clist.clearInner()
+	for _, value := range values {
+		clist.addLastInner(clist.newItem(value))
+	}
+	clist.unlock()
+}
+
+// Compact rebuilds the elements of this list into a single contiguous block of memory,
+// preserving their order. This improves the locality of traversals (ToArray, ForEach, etc.)
+// of a list whose elements were scattered in memory as a result of additions and removals.
+// It is intended for lists that become read-mostly after a build phase, the complexity of this method is O(n).
+// Note! The block is kept in memory while at least one of its elements is in the list.
+func (clist *ConcurrentLinkedList[T]) Compact() {
+	clist.mu.Lock()
+	defer clist.unlock()
+	if clist.size == 0 {
+		return
+	}
+	items := make([]listItem[T], clist.size)
+	i := 0
+	for item := clist.first; item != nil; item = item.next {
+		items[i].value = item.value
+		if i > 0 {
+			items[i].prev = &items[i-1]
+			items[i-1].next = &items[i]
+		}
+		i++
+	}
+	clist.first = &items[0]
+	clist.last = &items[len(items)-1]
+}
 
 // Size returns the number of elements in this list
 //
@@ -245,11 +582,78 @@ func (clist *ConcurrentLinkedList[T]) Size() int {
 	return clist.size
 } //revive:enable:confusing-naming
 
+// IsEmpty returns true if this list does not contain any elements
+//
+//revive:disable:confusing-naming
+func (clist *ConcurrentLinkedList[T]) IsEmpty() bool {
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	return clist.size == 0
+} //revive:enable:confusing-naming
+
+// Validate checks the internal consistency of this list and returns an error describing
+// the first inconsistency found or nil if the list is consistent.
+// It is intended for diagnostics and tests, the complexity of this method is O(n).
+func (clist *ConcurrentLinkedList[T]) Validate() error {
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	if clist.first != nil && clist.first.prev != nil {
+		return errors.New("the first item has a previous item")
+	}
+	if clist.last != nil && clist.last.next != nil {
+		return errors.New("the last item has a next item")
+	}
+	forward := 0
+	for item := clist.first; item != nil; item = item.next {
+		if item.next != nil && item.next.prev != item {
+			return fmt.Errorf("the item at index %d is not the previous item of its next item", forward)
+		}
+		if item.next == nil && item != clist.last {
+			return fmt.Errorf("the item at index %d has no next item, but it is not the last item", forward)
+		}
+		forward++
+		if forward > clist.size {
+			return fmt.Errorf("the forward traversal exceeds the list size %d", clist.size)
+		}
+	}
+	if forward != clist.size {
+		return fmt.Errorf("the forward traversal counted %d items, the list size is %d", forward, clist.size)
+	}
+	backward := 0
+	for item := clist.last; item != nil; item = item.prev {
+		backward++
+		if backward > clist.size {
+			return fmt.Errorf("the backward traversal exceeds the list size %d", clist.size)
+		}
+	}
+	if backward != clist.size {
+		return fmt.Errorf("the backward traversal counted %d items, the list size is %d", backward, clist.size)
+	}
+	return nil
+}
+
 // NewConcurrentLinkedList constructs an empty list
 func NewConcurrentLinkedList[T any]() *ConcurrentLinkedList[T] {
 	return &ConcurrentLinkedList[T]{}
 }
 
+// NewConcurrentLinkedListCapacity constructs an empty list with preallocated space for capacity elements,
+// so the first capacity additions of elements don't allocate memory.
+// The nodes of removed elements are not reused.
+//   - capacity - the number of preallocated elements
+func NewConcurrentLinkedListCapacity[T any](capacity int) *ConcurrentLinkedList[T] {
+	result := NewConcurrentLinkedList[T]()
+	if capacity <= 0 {
+		return result
+	}
+	items := make([]listItem[T], capacity)
+	for i := 0; i < capacity-1; i++ {
+		items[i].next = &items[i+1]
+	}
+	result.free = &items[0]
+	return result
+}
+
 // NewConcurrentLinkedListItems constructs a list containing the specified elements
 func NewConcurrentLinkedListItems[T any](values ...T) *ConcurrentLinkedList[T] {
 	result := NewConcurrentLinkedList[T]()
@@ -260,3 +664,131 @@ func NewConcurrentLinkedListItems[T any](values ...T) *ConcurrentLinkedList[T] {
 	result.mu.Unlock()
 	return result
 }
+
+// AddLastUnique appends specified element to the end of the list if the list does not contain it yet.
+// Returns true if the element was appended, otherwise returns false.
+//   - clist - the list to which the element is to be appended
+//   - value - the value to be appended
+func AddLastUnique[T comparable](clist *ConcurrentLinkedList[T], value T) bool {
+	clist.mu.Lock()
+	defer clist.unlock()
+	for item := clist.first; item != nil; item = item.next {
+		if item.value == value {
+			return false
+		}
+	}
+	clist.addLastInner(clist.newItem(value))
+	return true
+}
+
+// Reduce applies the function f to each element of the list from the first to the last element,
+// passing the result of the previous call as the accumulator. Returns the final value of the accumulator
+// or initial if the list is empty.
+//   - clist - the list to be reduced
+//   - initial - the initial value of the accumulator
+//   - f - the function that combines the accumulator with an element of the list
+func Reduce[T, A any](clist *ConcurrentLinkedList[T], initial A, f func(acc A, value T) A) A {
+	acc := initial
+	clist.mu.RLock()
+	for item := clist.first; item != nil; item = item.next {
+		acc = f(acc, item.value)
+	}
+	clist.mu.RUnlock()
+	return acc
+}
+
+// DistinctList removes duplicate elements from the list keeping the first occurrence of each element.
+// Returns the number of removed elements.
+//   - clist - the list from which the duplicates are to be removed
+func DistinctList[T comparable](clist *ConcurrentLinkedList[T]) int {
+	removed := 0
+	clist.mu.Lock()
+	present := make(map[T]struct{}, clist.size)
+	for item := clist.first; item != nil; item = item.next {
+		if _, ok := present[item.value]; ok {
+			clist.removeItem(item)
+			removed++
+		} else {
+			present[item.value] = struct{}{}
+		}
+	}
+	clist.unlock()
+	return removed
+}
+
+// ListContainsAll returns true if the list contains all the specified values.
+// The list is traversed only once, so the complexity of this function is O(n+m).
+//   - clist - the list to be checked
+//   - values - the values whose presence is to be checked
+func ListContainsAll[T comparable](clist *ConcurrentLinkedList[T], values ...T) bool {
+	pending := make(map[T]struct{}, len(values))
+	for _, value := range values {
+		pending[value] = struct{}{}
+	}
+	if len(pending) == 0 {
+		return true
+	}
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	for item := clist.first; item != nil; item = item.next {
+		delete(pending, item.value)
+		if len(pending) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ListIndexOf returns the index of the first occurrence of the specified value in the list,
+// or -1 if the list does not contain the value.
+//   - clist - the list to be searched
+//   - value - the value to search for
+func ListIndexOf[T comparable](clist *ConcurrentLinkedList[T], value T) int {
+	clist.mu.RLock()
+	defer clist.mu.RUnlock()
+	for i, item := 0, clist.first; item != nil; i, item = i+1, item.next {
+		if item.value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemoveFirstEqual removes from the list the first element that is equal to the specified value.
+// Returns the former index of the removed element and true, or -1 and false if the list does not contain the value.
+//   - clist - the list from which the element is to be removed
+//   - value - the value to be removed
+func RemoveFirstEqual[T comparable](clist *ConcurrentLinkedList[T], value T) (int, bool) {
+	clist.mu.Lock()
+	defer clist.unlock()
+	for i, item := 0, clist.first; item != nil; i, item = i+1, item.next {
+		if item.value == value {
+			clist.removeItem(item)
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// AddAllUnique appends to the end of the list the specified values that the list does not contain yet,
+// the duplicates within the values themselves are appended only once.
+// Returns the number of appended values.
+//   - clist - the list to which the values are to be appended
+//   - values - the values to be appended
+func AddAllUnique[T comparable](clist *ConcurrentLinkedList[T], values ...T) int {
+	added := 0
+	clist.mu.Lock()
+	present := make(map[T]struct{}, clist.size+len(values))
+	for item := clist.first; item != nil; item = item.next {
+		present[item.value] = struct{}{}
+	}
+	for _, value := range values {
+		if _, ok := present[value]; !ok {
+			present[value] = struct{}{}
+			clist.addLastInner(clist.newItem(value))
+			added++
+		}
+	}
+	clist.unlock()
+	return added
+}
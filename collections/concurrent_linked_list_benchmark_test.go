@@ -0,0 +1,104 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkConcurrentLinkedList_Compact(b *testing.B) {
+	const count = 100_000
+	createScattered := func() *ConcurrentLinkedList[int] {
+		r := rand.New(rand.NewSource(1))
+		list := NewConcurrentLinkedList[int]()
+		for i := 0; i < count; i++ {
+			if r.Intn(2) == 0 {
+				list.AddFirst(i)
+			} else {
+				list.AddLast(i)
+			}
+		}
+		// the removals leave gaps between the remaining elements
+		list.RemoveAll(func(value int) bool {
+			return value%3 == 0
+		})
+		return list
+	}
+	scattered := createScattered()
+	compacted := createScattered()
+	compacted.Compact()
+	benchmarks := []struct {
+		name string
+		list *ConcurrentLinkedList[int]
+	}{
+		{name: "scattered", list: scattered},
+		{name: "compacted", list: compacted},
+	}
+	for _, bm := range benchmarks {
+		list := bm.list
+		b.Run(bm.name+"_ToArray", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = list.ToArray()
+			}
+		})
+		b.Run(bm.name+"_ForEach", func(b *testing.B) {
+			sum := 0
+			for i := 0; i < b.N; i++ {
+				list.ForEach(func(value int) {
+					sum += value
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkNewConcurrentLinkedListCapacity(b *testing.B) {
+	const capacity = 1000
+	benchmarks := []struct {
+		name   string
+		create func() *ConcurrentLinkedList[int]
+	}{
+		{name: "NewConcurrentLinkedList", create: NewConcurrentLinkedList[int]},
+		{name: "NewConcurrentLinkedListCapacity", create: func() *ConcurrentLinkedList[int] {
+			return NewConcurrentLinkedListCapacity[int](capacity)
+		}},
+	}
+	for _, bm := range benchmarks {
+		create := bm.create
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				list := create()
+				b.StartTimer()
+				for j := 0; j < capacity; j++ {
+					list.AddLast(j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentLinkedList_ToArrayInto(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	list := NewConcurrentLinkedListItems[int](values...)
+	b.Run("ToArray", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = list.ToArray()
+		}
+	})
+	b.Run("ToArrayInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []int
+		for i := 0; i < b.N; i++ {
+			buf = list.ToArrayInto(buf)
+		}
+	})
+}
@@ -7,9 +7,13 @@ package collections
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"reflect"
+	"runtime"
+	"slices"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestLinkedList_example(t *testing.T) {
@@ -717,3 +721,498 @@ type listTestStruct struct {
 	name  string
 	value int
 }
+
+func TestConcurrentLinkedList_Random(t *testing.T) {
+	const (
+		size  = 10
+		draws = 100_000
+	)
+	values := make([]int, size)
+	for i := range values {
+		values[i] = i
+	}
+	list := NewConcurrentLinkedListItems[int](values...)
+	r := rand.New(rand.NewSource(1))
+	counts := make([]int, size)
+	for i := 0; i < draws; i++ {
+		value, ok := list.Random(r)
+		assert.True(t, ok)
+		counts[value]++
+	}
+	expected := draws / size
+	for index, count := range counts {
+		assert.InDelta(t, expected, count, float64(expected)/10, "index: %d, counts: %v", index, counts)
+	}
+}
+
+func TestConcurrentLinkedList_Random_empty(t *testing.T) {
+	list := NewConcurrentLinkedList[int]()
+	actual, ok := list.Random(rand.New(rand.NewSource(1)))
+	assert.False(t, ok)
+	assert.Equal(t, 0, actual)
+}
+
+func TestConcurrentLinkedList_Get_from_tail(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](0, 1, 2, 3, 4, 5, 6)
+	for i := 0; i < list.Size(); i++ {
+		actual, err := list.Get(i)
+		assert.Nil(t, err)
+		assert.Equal(t, i, actual)
+	}
+	_, err := list.Get(list.Size())
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
+
+func TestConcurrentLinkedList_ForEachRead(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](10, 20, 30)
+	var indexes, values, sizes []int
+	list.ForEachRead(func(index int, value int) {
+		indexes = append(indexes, index)
+		values = append(values, value)
+		sizes = append(sizes, list.Size())
+		actual, err := list.Get(index)
+		assert.Nil(t, err)
+		assert.Equal(t, value, actual)
+	})
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{10, 20, 30}, values)
+	assert.Equal(t, []int{3, 3, 3}, sizes)
+}
+
+func TestAddLastUnique(t *testing.T) {
+	list := NewConcurrentLinkedList[string]()
+	assert.True(t, AddLastUnique(list, "a"))
+	assert.True(t, AddLastUnique(list, "b"))
+	assert.False(t, AddLastUnique(list, "a"))
+	assert.True(t, AddLastUnique(list, "c"))
+	assert.False(t, AddLastUnique(list, "c"))
+	assert.False(t, AddLastUnique(list, "b"))
+	assert.Equal(t, []string{"a", "b", "c"}, list.ToArray())
+	assert.Equal(t, 3, list.Size())
+}
+
+func TestConcurrentLinkedList_Clear_unlinks_items(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	escaped := list.first
+	collected := make(chan struct{})
+	runtime.SetFinalizer(list.last, func(_ *listItem[int]) { close(collected) })
+
+	list.Clear()
+
+	assert.Equal(t, 0, list.Size())
+	assert.Nil(t, escaped.prev)
+	assert.Nil(t, escaped.next)
+	assert.Equal(t, 0, escaped.value)
+	deadline := time.After(5 * time.Second)
+	for finalized := false; !finalized; {
+		runtime.GC()
+		select {
+		case <-collected:
+			finalized = true
+		case <-deadline:
+			t.Fatal("the last item is still reachable after Clear()")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	runtime.KeepAlive(escaped)
+}
+
+func TestReduce(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4)
+	sum := Reduce(list, 0, func(acc int, value int) int { return acc + value })
+	assert.Equal(t, 10, sum)
+	str := Reduce(list, ">", func(acc string, value int) string { return fmt.Sprintf("%s%d", acc, value) })
+	assert.Equal(t, ">1234", str)
+	empty := Reduce(NewConcurrentLinkedList[int](), 123, func(acc int, value int) int { return acc + value })
+	assert.Equal(t, 123, empty)
+}
+
+func TestConcurrentLinkedList_UpdateAt(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5)
+	double := func(old int) int { return old * 2 }
+	for _, index := range []int{0, 2, 4} {
+		actual, err := list.UpdateAt(index, double)
+		assert.Nil(t, err)
+		assert.Equal(t, (index+1)*2, actual)
+	}
+	assert.Equal(t, []int{2, 2, 6, 4, 10}, list.ToArray())
+
+	actual, err := list.UpdateAt(5, double)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	assert.Equal(t, 0, actual)
+	_, err = list.UpdateAt(-1, double)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	assert.Equal(t, []int{2, 2, 6, 4, 10}, list.ToArray())
+}
+
+func TestConcurrentLinkedList_AppendList(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	other := NewConcurrentLinkedListItems[int](4, 5)
+	list.AppendList(other)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.ToArray())
+	assert.Equal(t, 5, list.Size())
+	assert.Equal(t, []int{4, 5}, other.ToArray(), "the other list must not be changed")
+
+	list.AppendList(NewConcurrentLinkedList[int]())
+	assert.Equal(t, 5, list.Size())
+
+	other.AppendList(other)
+	assert.Equal(t, []int{4, 5, 4, 5}, other.ToArray())
+}
+
+func TestDistinctList(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](3, 1, 3, 2, 1, 3, 4, 2)
+	assert.Equal(t, 4, DistinctList(list))
+	assert.Equal(t, []int{3, 1, 2, 4}, list.ToArray())
+	assert.Equal(t, 4, list.Size())
+	assert.Equal(t, 0, DistinctList(list))
+	assert.Equal(t, 0, DistinctList(NewConcurrentLinkedList[int]()))
+}
+
+func TestConcurrentLinkedList_DrainTo(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5)
+	small := make([]int, 2)
+	assert.Equal(t, 2, list.DrainTo(small))
+	assert.Equal(t, []int{1, 2}, small)
+	assert.Equal(t, []int{3, 4, 5}, list.ToArray())
+
+	large := make([]int, 10)
+	assert.Equal(t, 3, list.DrainTo(large))
+	assert.Equal(t, []int{3, 4, 5}, large[:3])
+	assert.Equal(t, 0, list.Size())
+	assert.Nil(t, list.first)
+	assert.Nil(t, list.last)
+
+	assert.Equal(t, 0, list.DrainTo(large))
+}
+
+func TestListContainsAll(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5)
+	assert.True(t, ListContainsAll(list, 5, 1, 3, 1))
+	assert.False(t, ListContainsAll(list, 1, 6))
+	assert.False(t, ListContainsAll(NewConcurrentLinkedList[int](), 1))
+	assert.True(t, ListContainsAll(list))
+	assert.True(t, ListContainsAll(NewConcurrentLinkedList[int]()))
+}
+
+func TestListIndexOf(t *testing.T) {
+	list := NewConcurrentLinkedListItems[string]("a", "b", "c", "b")
+	assert.Equal(t, 0, ListIndexOf(list, "a"))
+	assert.Equal(t, 1, ListIndexOf(list, "b"))
+	assert.Equal(t, 2, ListIndexOf(list, "c"))
+	assert.Equal(t, -1, ListIndexOf(list, "d"))
+	assert.Equal(t, -1, ListIndexOf(NewConcurrentLinkedList[string](), "a"))
+}
+
+func TestConcurrentLinkedList_OnSizeThreshold(t *testing.T) {
+	const (
+		high = 5
+		low  = 2
+	)
+	list := NewConcurrentLinkedList[int]()
+	highCount, lowCount := 0, 0
+	list.OnSizeThreshold(high, low, func() {
+		highCount++
+		assert.Equal(t, high+1, list.Size(), "the callback must be called outside the lock")
+	}, func() {
+		lowCount++
+	})
+	for i := 0; i < high; i++ {
+		list.AddLast(i)
+	}
+	assert.Equal(t, 0, highCount)
+	list.AddLast(high)
+	assert.Equal(t, 1, highCount)
+	list.AddFirst(-1)
+	list.RemoveFirst()
+	list.RemoveLast()
+	list.AddLast(high)
+	assert.Equal(t, 1, highCount, "no duplicate firing within the band")
+	assert.Equal(t, 0, lowCount)
+
+	for list.Size() > low {
+		list.RemoveFirst()
+	}
+	assert.Equal(t, 0, lowCount)
+	list.RemoveLast()
+	assert.Equal(t, 1, lowCount)
+	list.Clear()
+	list.AddLast(1)
+	list.AddLast(2)
+	list.AddLast(3)
+	assert.Equal(t, 1, lowCount, "no duplicate firing within the band")
+	assert.Equal(t, 1, highCount)
+
+	list.AppendList(NewConcurrentLinkedListItems[int](4, 5, 6))
+	assert.Equal(t, 2, highCount)
+	list.RemoveAll(func(value int) bool { return value > 0 })
+	assert.Equal(t, 2, lowCount)
+}
+
+func TestConcurrentLinkedList_RemoveFirstAndSize(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	for i := 1; i <= 3; i++ {
+		value, ok, size := list.RemoveFirstAndSize()
+		assert.True(t, ok)
+		assert.Equal(t, i, value)
+		assert.Equal(t, 3-i, size)
+	}
+	value, ok, size := list.RemoveFirstAndSize()
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+	assert.Equal(t, 0, size)
+}
+
+func TestConcurrentLinkedList_Validate(t *testing.T) {
+	list := NewConcurrentLinkedList[int]()
+	assert.Nil(t, list.Validate())
+	list.AddLast(2)
+	list.AddFirst(1)
+	list.AddLast(3)
+	list.AddLast(4)
+	_, _ = list.Remove(2)
+	assert.Nil(t, list.Validate())
+}
+
+func TestConcurrentLinkedList_Validate_corrupted(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(list *ConcurrentLinkedList[int])
+	}{
+		{name: "size", corrupt: func(list *ConcurrentLinkedList[int]) { list.size++ }},
+		{name: "first.prev", corrupt: func(list *ConcurrentLinkedList[int]) { list.first.prev = list.last }},
+		{name: "last.next", corrupt: func(list *ConcurrentLinkedList[int]) { list.last.next = list.first }},
+		{name: "next.prev", corrupt: func(list *ConcurrentLinkedList[int]) { list.first.next.prev = list.last }},
+		{name: "broken chain", corrupt: func(list *ConcurrentLinkedList[int]) {
+			list.first.next.next = nil
+		}},
+		{name: "backward", corrupt: func(list *ConcurrentLinkedList[int]) { list.last.prev = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewConcurrentLinkedListItems[int](1, 2, 3, 4)
+			tt.corrupt(list)
+			err := list.Validate()
+			assert.NotNil(t, err)
+			t.Log(err)
+		})
+	}
+}
+
+func TestAddAllUnique(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	assert.Equal(t, 3, AddAllUnique(list, 4, 2, 5, 4, 1, 6, 5))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, list.ToArray())
+	assert.Equal(t, 0, AddAllUnique(list, 1, 6))
+	assert.Equal(t, 0, AddAllUnique(list))
+	assert.Equal(t, 6, list.Size())
+}
+
+func TestConcurrentLinkedList_Compact(t *testing.T) {
+	list := NewConcurrentLinkedList[int]()
+	list.Compact()
+	assert.True(t, list.IsEmpty())
+	for i := 1; i <= 10; i++ {
+		list.AddLast(i)
+	}
+	list.AddFirst(0)
+	_, _ = list.Remove(5)
+	list.Compact()
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 6, 7, 8, 9, 10}, list.ToArray())
+
+	list.AddLast(11)
+	list.AddFirst(-1)
+	_, _ = list.Remove(3)
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, []int{-1, 0, 1, 3, 4, 6, 7, 8, 9, 10, 11}, list.ToArray())
+	last, _ := list.GetLast()
+	assert.Equal(t, 11, last)
+}
+
+func TestNewConcurrentLinkedListCapacity(t *testing.T) {
+	const capacity = 4
+	list := NewConcurrentLinkedListCapacity[int](capacity)
+	expected := NewConcurrentLinkedList[int]()
+	assert.True(t, list.IsEmpty())
+	for i := 0; i < capacity+2; i++ {
+		list.AddLast(i)
+		expected.AddLast(i)
+		list.AddFirst(-i)
+		expected.AddFirst(-i)
+	}
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, expected.ToArray(), list.ToArray())
+	assert.Equal(t, expected.Size(), list.Size())
+	_, _ = list.Remove(3)
+	_, _ = expected.Remove(3)
+	list.RemoveFirst()
+	expected.RemoveFirst()
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, expected.ToArray(), list.ToArray())
+	assert.True(t, NewConcurrentLinkedListCapacity[int](0).IsEmpty())
+}
+
+func TestConcurrentLinkedList_ReplaceAllContents(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	list.ReplaceAllContents(4, 5)
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, []int{4, 5}, list.ToArray())
+	list.ReplaceAllContents()
+	assert.True(t, list.IsEmpty())
+}
+
+func TestConcurrentLinkedList_ReplaceAllContents_concurrent(t *testing.T) {
+	oldValues := []int{1, 2, 3, 4, 5}
+	newValues := []int{10, 20, 30}
+	list := NewConcurrentLinkedListItems[int](oldValues...)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if i%2 == 0 {
+				list.ReplaceAllContents(newValues...)
+			} else {
+				list.ReplaceAllContents(oldValues...)
+			}
+		}
+		close(stop)
+	}()
+	for running := true; running; {
+		select {
+		case <-stop:
+			running = false
+		default:
+			actual := list.ToArray()
+			if !slices.Equal(oldValues, actual) && !slices.Equal(newValues, actual) {
+				t.Fatal("unexpected list contents:", actual)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func TestConcurrentLinkedList_ToArrayInto(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	buf := make([]int, 5, 10)
+	actual := list.ToArrayInto(buf)
+	assert.Equal(t, list.ToArray(), actual)
+	assert.Equal(t, &buf[0], &actual[0], "the buffer should be reused")
+
+	list.AddLast(4)
+	actual = list.ToArrayInto(nil)
+	assert.Equal(t, []int{1, 2, 3, 4}, actual)
+	small := make([]int, 0, 1)
+	assert.Equal(t, list.ToArray(), list.ToArrayInto(small))
+	assert.Empty(t, NewConcurrentLinkedList[int]().ToArrayInto(buf))
+}
+
+func TestRemoveFirstEqual(t *testing.T) {
+	list := NewConcurrentLinkedListItems[string]("a", "b", "c", "b")
+	index, ok := RemoveFirstEqual(list, "b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, []string{"a", "c", "b"}, list.ToArray())
+	assert.Nil(t, list.Validate())
+
+	index, ok = RemoveFirstEqual(list, "x")
+	assert.False(t, ok)
+	assert.Equal(t, -1, index)
+	assert.Equal(t, 3, list.Size())
+
+	index, ok = RemoveFirstEqual(list, "b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, []string{"a", "c"}, list.ToArray())
+}
+
+func TestConcurrentLinkedList_ContainsFunc(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5)
+	calls := 0
+	assert.True(t, list.ContainsFunc(func(value int) bool {
+		calls++
+		return value == 1
+	}))
+	assert.Equal(t, 1, calls, "the traversal should stop at the first match")
+
+	calls = 0
+	assert.True(t, list.ContainsFunc(func(value int) bool {
+		calls++
+		return value > 4
+	}))
+	assert.Equal(t, 5, calls)
+
+	assert.False(t, list.ContainsFunc(func(value int) bool { return value > 5 }))
+	assert.False(t, NewConcurrentLinkedList[int]().ContainsFunc(func(value int) bool { return true }))
+}
+
+func TestConcurrentLinkedList_Move(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		expected []int
+	}{
+		{name: "forward", from: 1, to: 3, expected: []int{0, 2, 3, 1, 4}},
+		{name: "backward", from: 3, to: 1, expected: []int{0, 3, 1, 2, 4}},
+		{name: "to head", from: 2, to: 0, expected: []int{2, 0, 1, 3, 4}},
+		{name: "to tail", from: 1, to: 4, expected: []int{0, 2, 3, 4, 1}},
+		{name: "head to tail", from: 0, to: 4, expected: []int{1, 2, 3, 4, 0}},
+		{name: "tail to head", from: 4, to: 0, expected: []int{4, 0, 1, 2, 3}},
+		{name: "no-op", from: 2, to: 2, expected: []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			list := NewConcurrentLinkedListItems[int](0, 1, 2, 3, 4)
+			assert.Nil(t, list.Move(test.from, test.to))
+			assert.Nil(t, list.Validate())
+			assert.Equal(t, test.expected, list.ToArray())
+		})
+	}
+}
+
+func TestConcurrentLinkedList_Move_fail(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](0, 1, 2)
+	assert.ErrorIs(t, list.Move(-1, 0), ErrIndexOutOfRange)
+	assert.ErrorIs(t, list.Move(3, 0), ErrIndexOutOfRange)
+	assert.ErrorIs(t, list.Move(0, 3), ErrIndexOutOfRange)
+	assert.ErrorIs(t, list.Move(0, -1), ErrIndexOutOfRange)
+	assert.Equal(t, []int{0, 1, 2}, list.ToArray())
+	assert.ErrorIs(t, NewConcurrentLinkedList[int]().Move(0, 0), ErrIndexOutOfRange)
+}
+
+func TestConcurrentLinkedList_DrainChannel(t *testing.T) {
+	list := NewConcurrentLinkedList[int]()
+	for i := 0; i < 10; i++ {
+		list.AddLast(i)
+	}
+	var batches [][]int
+	for batch := range list.DrainChannel(3) {
+		batches = append(batches, batch)
+	}
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9}}, batches)
+	assert.True(t, list.IsEmpty())
+
+	_, ok := <-NewConcurrentLinkedList[int]().DrainChannel(0)
+	assert.False(t, ok, "the channel should be closed for an empty list")
+}
+
+func TestConcurrentLinkedList_DrainChannelDone(t *testing.T) {
+	list := NewConcurrentLinkedList[int]()
+	for i := 0; i < 10; i++ {
+		list.AddLast(i)
+	}
+	done := make(chan struct{})
+	ch := list.DrainChannelDone(done, 3)
+	assert.Equal(t, []int{0, 1, 2}, <-ch)
+	close(done)
+	assert.Eventually(t, func() bool {
+		return list.Size() == 7
+	}, time.Second, time.Millisecond, "the undelivered batch should be put back")
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8, 9}, list.ToSlice())
+	_, ok := <-ch
+	assert.False(t, ok, "the channel should be closed after the done channel is closed")
+	assert.NoError(t, list.Validate())
+}
@@ -5,7 +5,12 @@
 // Package collections contains some thread safe collections.
 package collections
 
-import "sync"
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"unsafe"
+)
 
 // ConcurrentMap is a thread safe map.
 // A ConcurrentMap is safe for concurrent use by multiple goroutines.
@@ -15,6 +20,8 @@ type ConcurrentMap[K comparable, V any] struct {
 	mu       sync.RWMutex
 	mp       map[K]V
 	capacity int
+	deferred deferredWrites[K, V]
+	autoTrim autoTrim
 }
 
 // ForEachRead performs a given action for each (key, value)
@@ -32,6 +39,21 @@ func (cmap *ConcurrentMap[K, V]) ForEachRead(f func(key K, value V)) {
 	cmap.mu.RUnlock()
 }
 
+// StableForEach performs a given action for each (key, value) pair in the order of the keys
+// sorted with the specified comparator, which makes the iteration deterministic.
+// The pairs are copied under the read lock, the keys are sorted and the 'f' function is called
+// after the lock is released, so ConcurrentMap methods can be used inside the 'f' function.
+//   - less - the function that reports whether the key a must sort before the key b
+//   - f - the function, that will be called for each (key, value) pair in ConcurrentMap
+func (cmap *ConcurrentMap[K, V]) StableForEach(less func(a, b K) bool, f func(key K, value V)) {
+	entries := cmap.SortedEntries(func(a, b Entry[K, V]) bool {
+		return less(a.Key, b.Key)
+	})
+	for _, entry := range entries {
+		f(entry.Key, entry.Value)
+	}
+}
+
 // ForEach performs a given action for each (key, value)
 //   - f - the function, that will be called for each (key, value) pair in ConcurrentMap
 //
@@ -47,6 +69,26 @@ func (cmap *ConcurrentMap[K, V]) ForEach(f func(key K, value V)) {
 	cmap.mu.Unlock()
 } //revive:enable:confusing-naming
 
+// ForEachParallel performs a given action for each (key, value) pair using the specified number of goroutines
+// and waits for all of them to complete. It is intended for independent CPU-bound processing of the pairs.
+// The pairs are copied under the read lock and processed after the lock is released,
+// so ConcurrentMap methods can be used inside the 'f' function.
+//   - workers - the number of goroutines, a value less than 1 is treated as 1
+//   - f - the function, that will be called for each (key, value) pair in ConcurrentMap
+//
+// Note! The 'f' function is called concurrently, so it must be safe for concurrent use.
+func (cmap *ConcurrentMap[K, V]) ForEachParallel(workers int, f func(key K, value V)) {
+	cmap.mu.RLock()
+	entries := make([]Entry[K, V], 0, len(cmap.mp))
+	for k, v := range cmap.mp {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+	}
+	cmap.mu.RUnlock()
+	forEachParallel(entries, workers, func(entry Entry[K, V]) {
+		f(entry.Key, entry.Value)
+	})
+}
+
 // PutIfNotExists maps the specified key (key) to the specified value (value)
 // if the key doesn't exist returns true and a new value (value).
 // If the key exists, the new value will not be mapped to it, the method returns false and the previous key (key) value.
@@ -62,6 +104,66 @@ func (cmap *ConcurrentMap[K, V]) PutIfNotExists(key K, value V) (bool, V) {
 	return true, value
 }
 
+// GetOrPutDefault returns the value to which the specified key is mapped,
+// if the key doesn't exist, maps it to the specified default value and returns this value.
+// It does the same thing as PutIfNotExists, but returns only the resulting value of the key.
+//   - key - the key whose value will be returned
+//   - def - the value to be associated with the key if it doesn't exist
+func (cmap *ConcurrentMap[K, V]) GetOrPutDefault(key K, def V) V {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	if val, ok := cmap.mp[key]; ok {
+		return val
+	}
+	cmap.mp[key] = def
+	return def
+}
+
+// GetOrCompute returns the value to which the specified key is mapped and false,
+// if the key doesn't exist, maps it to the result of the compute function and returns this value and true.
+// The compute function is called under the write lock, so concurrent calls for the same missing key
+// compute the value only once.
+//   - key - the key whose value will be returned
+//   - compute - the function that computes the value for the missing key
+//
+// Note! Do NOT USE ConcurrentMap methods inside the 'compute' function, as this will cause a deadlock.
+func (cmap *ConcurrentMap[K, V]) GetOrCompute(key K, compute func() V) (V, bool) {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	if val, ok := cmap.mp[key]; ok {
+		return val, false
+	}
+	val := compute()
+	cmap.mp[key] = val
+	return val, true
+}
+
+// Compute atomically updates the value to which the specified key is mapped.
+// The remap function is called under the write lock with the current value of the key and the sign of its existence.
+// If the remap function returns true, the returned value is mapped to the key,
+// otherwise the key is removed. Returns the new value and true if the key exists after the call,
+// otherwise the default value for the value type and false.
+//   - key - the key whose value is to be updated
+//   - remap - the function that returns the new value and the sign of whether it should be kept
+//
+// Note! Do NOT USE ConcurrentMap methods inside the 'remap' function, as this will cause a deadlock.
+func (cmap *ConcurrentMap[K, V]) Compute(key K, remap func(old V, exists bool) (V, bool)) (V, bool) {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	old, exists := cmap.mp[key]
+	val, keep := remap(old, exists)
+	if !keep {
+		if exists {
+			delete(cmap.mp, key)
+			cmap.removedInner()
+		}
+		var res V
+		return res, false
+	}
+	cmap.mp[key] = val
+	return val, true
+}
+
 // PutIfNotExistsDoubleCheck does the same thing as PutIfNotExists, but before doing so,
 // it checks the existence of the key (key) using the Get method.
 //   - key - the key with which a specified value is to be assigned
@@ -92,11 +194,13 @@ func (cmap *ConcurrentMap[K, V]) RemoveIfExistsDoubleCheck(key K) (bool, V) {
 func (cmap *ConcurrentMap[K, V]) RemoveIfExists(key K) (bool, V) {
 	cmap.mu.Lock()
 	defer cmap.mu.Unlock()
+	cmap.discardDeferredInner(key)
 	old, ok := cmap.mp[key]
 	if !ok {
 		return false, old
 	}
 	delete(cmap.mp, key)
+	cmap.removedInner()
 	return true, old
 }
 
@@ -106,7 +210,12 @@ func (cmap *ConcurrentMap[K, V]) RemoveIfExists(key K) (bool, V) {
 //revive:disable:confusing-naming
 func (cmap *ConcurrentMap[K, V]) Remove(key K) {
 	cmap.mu.Lock()
+	size := len(cmap.mp)
 	delete(cmap.mp, key)
+	if len(cmap.mp) < size {
+		cmap.removedInner()
+	}
+	cmap.discardDeferredInner(key)
 	cmap.mu.Unlock()
 } //revive:enable:confusing-naming
 
@@ -117,9 +226,55 @@ func (cmap *ConcurrentMap[K, V]) Remove(key K) {
 func (cmap *ConcurrentMap[K, V]) Put(key K, value V) {
 	cmap.mu.Lock()
 	cmap.mp[key] = value
+	cmap.discardDeferredInner(key)
 	cmap.mu.Unlock()
 }
 
+// LoadFromChannelBatchSize is the max number of entries that LoadFromChannel puts under a single lock
+const LoadFromChannelBatchSize = 256
+
+// LoadFromChannel puts the entries received from the channel into this map until the channel is closed.
+// The entries are put in batches: after an entry is received, the entries that are already available
+// in the channel are taken without waiting (up to LoadFromChannelBatchSize)
+// and the whole batch is put under a single write lock.
+//   - ch - the channel of the entries to be put
+func (cmap *ConcurrentMap[K, V]) LoadFromChannel(ch <-chan Entry[K, V]) {
+	batch := make([]Entry[K, V], 0, LoadFromChannelBatchSize)
+	for entry := range ch {
+		batch = append(batch[:0], entry)
+	fill:
+		for len(batch) < LoadFromChannelBatchSize {
+			select {
+			case next, ok := <-ch:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+		cmap.mu.Lock()
+		for _, e := range batch {
+			cmap.mp[e.Key] = e.Value
+		}
+		cmap.mu.Unlock()
+	}
+}
+
+// GetAndPut maps the specified key (key) to the specified value (value)
+// and returns the previous value of the key and the sign of its existence.
+// If the key didn't exist, the default value for the value type and false are returned.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (cmap *ConcurrentMap[K, V]) GetAndPut(key K, value V) (V, bool) {
+	cmap.mu.Lock()
+	old, ok := cmap.mp[key]
+	cmap.mp[key] = value
+	cmap.mu.Unlock()
+	return old, ok
+}
+
 // Get returns the value to which the specified key is mapped and the sign of existence of this value.
 //   - key - the key whose value will be returned
 //
@@ -134,6 +289,37 @@ func (cmap *ConcurrentMap[K, V]) Get(key K) (V, bool) {
 	return val, ok
 } //revive:enable:confusing-naming
 
+// TryGet does the same thing as Get, but doesn't wait if the map is locked for writing.
+// Returns the value, the sign of its existence and true if the read lock was acquired,
+// otherwise returns the default value for the value type, false and false.
+//   - key - the key whose value will be returned
+func (cmap *ConcurrentMap[K, V]) TryGet(key K) (V, bool, bool) {
+	if !cmap.mu.TryRLock() {
+		var res V
+		return res, false, false
+	}
+	val, ok := cmap.mp[key]
+	cmap.mu.RUnlock()
+	return val, ok, true
+}
+
+// GetMulti returns the values of the specified keys that exist in this map and the keys that don't exist.
+// All the keys are read under a single read lock, so the result is a consistent snapshot of the map.
+//   - keys - the keys whose values will be returned
+func (cmap *ConcurrentMap[K, V]) GetMulti(keys ...K) (found map[K]V, missing []K) {
+	found = make(map[K]V, len(keys))
+	cmap.mu.RLock()
+	for _, key := range keys {
+		if val, ok := cmap.mp[key]; ok {
+			found[key] = val
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	cmap.mu.RUnlock()
+	return found, missing
+}
+
 // Keys returns a slice of the keys contained in this map
 func (cmap *ConcurrentMap[K, V]) Keys() []K {
 	cmap.mu.RLock()
@@ -145,6 +331,67 @@ func (cmap *ConcurrentMap[K, V]) Keys() []K {
 	return result
 }
 
+// MissingKeys returns the values of the specified set that aren't keys of this map.
+// The set is copied first, so the set and the map are never locked at the same time.
+//   - required - the set of the keys to be checked
+func (cmap *ConcurrentMap[K, V]) MissingKeys(required *ConcurrentSet[K]) []K {
+	keys := required.ToSlice()
+	missing := keys[:0]
+	cmap.mu.RLock()
+	for _, key := range keys {
+		if _, ok := cmap.mp[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	cmap.mu.RUnlock()
+	return missing
+}
+
+// Values returns a slice of the values contained in this map, the order of the values is unspecified
+func (cmap *ConcurrentMap[K, V]) Values() []V {
+	cmap.mu.RLock()
+	result := make([]V, 0, len(cmap.mp))
+	for _, v := range cmap.mp {
+		result = append(result, v)
+	}
+	cmap.mu.RUnlock()
+	return result
+}
+
+// KeySet returns a new ConcurrentSet containing the keys of this map
+func (cmap *ConcurrentMap[K, V]) KeySet() *ConcurrentSet[K] {
+	cmap.mu.RLock()
+	result := NewConcurrentSetCapacity[K](len(cmap.mp))
+	for k := range cmap.mp {
+		result.mp[k] = struct{}{}
+	}
+	cmap.mu.RUnlock()
+	return result
+}
+
+// SortedEntries returns a slice of the entries of this map sorted by the specified comparator.
+// The entries are copied under the read lock and the copy is sorted outside the lock.
+//   - less - the function that reports whether the entry a must sort before the entry b
+func (cmap *ConcurrentMap[K, V]) SortedEntries(less func(a, b Entry[K, V]) bool) []Entry[K, V] {
+	cmap.mu.RLock()
+	result := make([]Entry[K, V], 0, len(cmap.mp))
+	for k, v := range cmap.mp {
+		result = append(result, Entry[K, V]{Key: k, Value: v})
+	}
+	cmap.mu.RUnlock()
+	slices.SortFunc(result, func(a, b Entry[K, V]) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return result
+}
+
 // Size returns the number of key-value mappings in this map.
 //
 //revive:disable:confusing-naming
@@ -174,32 +421,86 @@ func (cmap *ConcurrentMap[K, V]) Copy() map[K]V {
 	return result
 }
 
+// CopyInto clears the specified map and fills it with the contents of this ConcurrentMap.
+// It does the same thing as Copy, but allows reusing the destination map across calls.
+// Note! dst must not be nil: a nil map can't be filled, so the method panics if this ConcurrentMap is not empty.
+//   - dst - the map to be filled, must not be nil
+func (cmap *ConcurrentMap[K, V]) CopyInto(dst map[K]V) {
+	clear(dst)
+	cmap.mu.RLock()
+	defer cmap.mu.RUnlock()
+	for key, value := range cmap.mp {
+		dst[key] = value
+	}
+}
+
 // TrimToSize trims the capacity of this ConcurrentMap instance to be the map's current size.
 // An application can use this operation to minimize the storage of a ConcurrentMap instance.
 //
 //revive:disable:confusing-naming
 func (cmap *ConcurrentMap[K, V]) TrimToSize() {
 	cmap.mu.Lock()
+	cmap.trimInner()
+	cmap.mu.Unlock()
+} //revive:enable:confusing-naming
+
+// trimInner replaces the underlying map with a copy whose capacity is the current size of the map.
+func (cmap *ConcurrentMap[K, V]) trimInner() {
 	tmp := make(map[K]V, len(cmap.mp))
 	for k, v := range cmap.mp {
 		tmp[k] = v
 	}
 	cmap.mp = tmp
+}
+
+// Grow grows the capacity of this ConcurrentMap instance, so that at least n more key-value mappings
+// can be put into it without incremental growing of the map.
+// An application can use this operation before putting a large batch of key-value mappings.
+//   - n - the number of key-value mappings to be put
+func (cmap *ConcurrentMap[K, V]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	cmap.mu.Lock()
+	tmp := make(map[K]V, len(cmap.mp)+n)
+	for k, v := range cmap.mp {
+		tmp[k] = v
+	}
+	cmap.mp = tmp
 	cmap.mu.Unlock()
-} //revive:enable:confusing-naming
+}
 
 // Clear clears the map
 //
 //revive:disable:confusing-naming
 func (cmap *ConcurrentMap[K, V]) Clear() {
 	cmap.mu.Lock()
+	cmap.clearInner()
+	cmap.mu.Unlock()
+} //revive:enable:confusing-naming
+func (cmap *ConcurrentMap[K, V]) clearInner() {
+	cmap.discardAllDeferredInner()
 	if cmap.capacity > 0 {
 		cmap.mp = make(map[K]V, cmap.capacity)
 	} else {
 		cmap.mp = make(map[K]V)
 	}
-	cmap.mu.Unlock()
-} //revive:enable:confusing-naming
+}
+
+// ClearIf clears the map only if the pred function returns true for the current size of the map.
+// Returns true if the map was cleared.
+//   - pred - the function that decides whether the map should be cleared
+//
+// Note! Do NOT USE ConcurrentMap methods inside the 'pred' function, as this will cause a deadlock.
+func (cmap *ConcurrentMap[K, V]) ClearIf(pred func(size int) bool) bool {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	if !pred(len(cmap.mp)) {
+		return false
+	}
+	cmap.clearInner()
+	return true
+}
 
 // NewConcurrentMap creates and returns a new empty ConcurrentMap instance.
 //   - K - comparable key type;
@@ -215,3 +516,117 @@ func NewConcurrentMap[K comparable, V any]() *ConcurrentMap[K, V] {
 func NewConcurrentMapCapacity[K comparable, V any](capacity int) *ConcurrentMap[K, V] {
 	return &ConcurrentMap[K, V]{mp: make(map[K]V, capacity), capacity: capacity}
 }
+
+// ContainsValue returns true if the ConcurrentMap contains at least one key mapped to the specified value.
+// This function scans all values of the map, so its complexity is O(n).
+//   - cmap - the map to be scanned
+//   - value - the value whose presence is to be checked
+func ContainsValue[K, V comparable](cmap *ConcurrentMap[K, V], value V) bool {
+	cmap.mu.RLock()
+	defer cmap.mu.RUnlock()
+	for _, v := range cmap.mp {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ReduceMap applies the function f to each (key, value) pair of the map, passing the result of the previous call
+// as the accumulator. Returns the final value of the accumulator or initial if the map is empty.
+// The pairs are visited in an unspecified order.
+//   - cmap - the map to be reduced
+//   - initial - the initial value of the accumulator
+//   - f - the function that combines the accumulator with a (key, value) pair of the map
+func ReduceMap[K comparable, V, A any](cmap *ConcurrentMap[K, V], initial A, f func(acc A, k K, v V) A) A {
+	acc := initial
+	cmap.mu.RLock()
+	for k, v := range cmap.mp {
+		acc = f(acc, k, v)
+	}
+	cmap.mu.RUnlock()
+	return acc
+}
+
+// MoveEntry removes the key from the 'from' map and puts it with its value into the 'to' map as a single operation.
+// Returns the moved value and true if the key existed in the 'from' map,
+// otherwise returns the default value for the value type and false, in this case the maps are not changed.
+// Both maps are locked in the order of their addresses, so concurrent calls with swapped maps don't cause a deadlock.
+//   - from - the map from which the key is to be removed
+//   - to - the map into which the key is to be put
+//   - key - the key to be moved
+func MoveEntry[K comparable, V any](from, to *ConcurrentMap[K, V], key K) (V, bool) {
+	if from == to {
+		return from.Get(key)
+	}
+	first, second := from, to
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	second.mu.Lock()
+	value, ok := from.mp[key]
+	if ok {
+		delete(from.mp, key)
+		to.mp[key] = value
+	}
+	second.mu.Unlock()
+	first.mu.Unlock()
+	return value, ok
+}
+
+// ValuesSet returns a new ConcurrentSet containing the distinct values of the map.
+//   - cmap - the map whose values are to be added to the set
+func ValuesSet[K, V comparable](cmap *ConcurrentMap[K, V]) *ConcurrentSet[V] {
+	result := NewConcurrentSet[V]()
+	cmap.mu.RLock()
+	for _, v := range cmap.mp {
+		result.mp[v] = struct{}{}
+	}
+	cmap.mu.RUnlock()
+	return result
+}
+
+// PageKeys returns a page of the map keys for pagination.
+// The keys are sorted in ascending order first, so the pages are deterministic
+// as long as the map isn't modified between calls.
+// Since the keys are sorted on each call, its complexity is O(n*log(n)).
+//   - cmap - the map whose keys are to be returned
+//   - offset - the number of keys to be skipped
+//   - limit - the max number of keys on the page
+func PageKeys[K cmp.Ordered, V any](cmap *ConcurrentMap[K, V], offset, limit int) []K {
+	keys := cmap.Keys()
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || offset >= len(keys) {
+		return []K{}
+	}
+	slices.Sort(keys)
+	end := min(offset+limit, len(keys))
+	result := make([]K, end-offset)
+	copy(result, keys[offset:end])
+	return result
+}
+
+// DecrementAndRemoveAtZero atomically decrements the counter to which the specified key is mapped
+// and removes the key if the counter reaches zero. It is useful for reference counting.
+// Returns the new value of the counter and true if the key still exists after the call.
+// If the key doesn't exist, the map isn't changed and 0 and false are returned.
+//   - cmap - the map of counters
+//   - key - the key whose counter is to be decremented
+func DecrementAndRemoveAtZero[K comparable](cmap *ConcurrentMap[K, int], key K) (int, bool) {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	count, ok := cmap.mp[key]
+	if !ok {
+		return 0, false
+	}
+	count--
+	if count <= 0 {
+		delete(cmap.mp, key)
+		return count, false
+	}
+	cmap.mp[key] = count
+	return count, true
+}
@@ -0,0 +1,50 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+// DefaultAutoTrimShrinkBelow is the fraction of the peak size used by NewConcurrentMapAutoTrim
+// when the specified fraction is outside the range (0, 1)
+const DefaultAutoTrimShrinkBelow = 0.25
+
+// autoTrim contains the state of the automatic trimming of a ConcurrentMap, see NewConcurrentMapAutoTrim.
+// It is protected by the map lock.
+type autoTrim struct {
+	// shrinkBelow is the fraction of the peak size below which the map is trimmed, 0 disables trimming
+	shrinkBelow float64
+	// peak is the max size of the map since the last trimming
+	peak int
+}
+
+// removedInner must be called under the write lock after a key was removed from the map.
+// It trims the map if its size falls below the configured fraction of the peak size.
+func (cmap *ConcurrentMap[K, V]) removedInner() {
+	if cmap.autoTrim.shrinkBelow <= 0 {
+		return
+	}
+	size := len(cmap.mp)
+	cmap.autoTrim.peak = max(cmap.autoTrim.peak, size+1)
+	if float64(size) < cmap.autoTrim.shrinkBelow*float64(cmap.autoTrim.peak) {
+		cmap.trimInner()
+		cmap.autoTrim.peak = size
+	}
+}
+
+// NewConcurrentMapAutoTrim creates and returns a new empty ConcurrentMap instance that trims itself
+// (see TrimToSize) after bulk removals. The map tracks its peak size and, when the size after
+// a Remove or RemoveIfExists call falls below shrinkWhenLoadBelow * peak, trims its storage
+// and resets the peak to the current size.
+// Other methods that remove keys (e.g. Clear, ClearIf) don't trigger trimming.
+// If shrinkWhenLoadBelow is outside the range (0, 1), DefaultAutoTrimShrinkBelow is used instead.
+//   - K - comparable key type;
+//   - V - value type;
+//   - shrinkWhenLoadBelow - the fraction of the peak size below which the map is trimmed, e.g. 0.25.
+func NewConcurrentMapAutoTrim[K comparable, V any](shrinkWhenLoadBelow float64) *ConcurrentMap[K, V] {
+	if !(shrinkWhenLoadBelow > 0 && shrinkWhenLoadBelow < 1) {
+		shrinkWhenLoadBelow = DefaultAutoTrimShrinkBelow
+	}
+	result := NewConcurrentMap[K, V]()
+	result.autoTrim.shrinkBelow = shrinkWhenLoadBelow
+	return result
+}
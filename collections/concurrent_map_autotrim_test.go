@@ -0,0 +1,76 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNewConcurrentMapAutoTrim(t *testing.T) {
+	const count = 10_000
+	cm := NewConcurrentMapAutoTrim[int, int](0.25)
+	for i := 0; i < count; i++ {
+		cm.Put(i, i)
+	}
+	backing := reflect.ValueOf(cm.mp).Pointer()
+	trimmedAt := -1
+	for i := 0; i < count; i++ {
+		if i%2 == 0 {
+			cm.Remove(i)
+		} else {
+			cm.RemoveIfExists(i)
+		}
+		if trimmedAt < 0 && reflect.ValueOf(cm.mp).Pointer() != backing {
+			trimmedAt = cm.Size()
+		}
+	}
+	assert.Equal(t, count/4-1, trimmedAt, "the map should be trimmed when its size falls below a quarter of the peak")
+	assert.True(t, cm.IsEmpty())
+}
+
+func TestNewConcurrentMapAutoTrim_values(t *testing.T) {
+	cm := NewConcurrentMapAutoTrim[int, string](0.5)
+	for i := 0; i < 10; i++ {
+		cm.Put(i, "value")
+	}
+	for i := 0; i < 8; i++ {
+		cm.Remove(i)
+	}
+	cm.Remove(100)
+	assert.Equal(t, map[int]string{8: "value", 9: "value"}, cm.Copy())
+	// trimmed at the size 4 (< 0.5 * 10), the next removals do not fall below 0.5 * 4
+	assert.Equal(t, 4, cm.autoTrim.peak)
+
+	backing := reflect.ValueOf(cm.mp).Pointer()
+	plain := NewConcurrentMap[int, string]()
+	plain.Put(1, "value")
+	plainBacking := reflect.ValueOf(plain.mp).Pointer()
+	plain.Remove(1)
+	assert.Equal(t, plainBacking, reflect.ValueOf(plain.mp).Pointer(), "a plain map should not be trimmed")
+	assert.Equal(t, backing, reflect.ValueOf(cm.mp).Pointer())
+}
+
+func TestNewConcurrentMapAutoTrim_outOfRange(t *testing.T) {
+	for _, fraction := range []float64{0, -0.5, 1, 1.5, math.NaN()} {
+		cm := NewConcurrentMapAutoTrim[int, int](fraction)
+		assert.Equal(t, DefaultAutoTrimShrinkBelow, cm.autoTrim.shrinkBelow, "fraction: %v", fraction)
+		for i := 0; i < 8; i++ {
+			cm.Put(i, i)
+		}
+		trims := 0
+		backing := reflect.ValueOf(cm.mp).Pointer()
+		for i := 0; i < 8; i++ {
+			cm.Remove(i)
+			if current := reflect.ValueOf(cm.mp).Pointer(); current != backing {
+				trims++
+				backing = current
+			}
+		}
+		assert.Less(t, trims, 8, "the map must not be trimmed on every removal, fraction: %v", fraction)
+	}
+}
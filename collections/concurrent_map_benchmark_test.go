@@ -6,6 +6,7 @@ package collections
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -98,3 +99,83 @@ func BenchmarkConcurrentMap_Put(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkConcurrentMap_Grow(b *testing.B) {
+	const count = 10_000
+	benchmarks := []struct {
+		name string
+		grow bool
+	}{
+		{name: "without Grow", grow: false},
+		{name: "with Grow", grow: true},
+	}
+	for _, bm := range benchmarks {
+		bmv := bm
+		b.Run(bmv.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cm := NewConcurrentMap[int, int]()
+				if bmv.grow {
+					cm.Grow(count)
+				}
+				for j := 0; j < count; j++ {
+					cm.Put(j, j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentMap_LoadFromChannel(b *testing.B) {
+	const count = 100_000
+	feed := func(ch chan<- Entry[int, int]) {
+		for i := 0; i < count; i++ {
+			ch <- Entry[int, int]{Key: i, Value: i}
+		}
+		close(ch)
+	}
+	b.Run("Put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cm := NewConcurrentMapCapacity[int, int](count)
+			ch := make(chan Entry[int, int], LoadFromChannelBatchSize)
+			go feed(ch)
+			for entry := range ch {
+				cm.Put(entry.Key, entry.Value)
+			}
+		}
+	})
+	b.Run("LoadFromChannel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cm := NewConcurrentMapCapacity[int, int](count)
+			ch := make(chan Entry[int, int], LoadFromChannelBatchSize)
+			go feed(ch)
+			cm.LoadFromChannel(ch)
+		}
+	})
+}
+
+func BenchmarkConcurrentMap_ForEachParallel(b *testing.B) {
+	const count = 1000
+	cm := NewConcurrentMapCapacity[int, int](count)
+	for i := 0; i < count; i++ {
+		cm.Put(i, i)
+	}
+	var sink atomic.Int64
+	cpuBound := func(key int, value int) {
+		res := value
+		for i := 0; i < 10_000; i++ {
+			res = res*31 + i
+		}
+		sink.Add(int64(res))
+	}
+	b.Run("ForEach", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cm.ForEach(cpuBound)
+		}
+	})
+	b.Run("ForEachParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cm.ForEachParallel(runtime.NumCPU(), cpuBound)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultDeferredFlushInterval is the flush interval used by NewConcurrentMapDeferred
+// when a non-positive interval is specified
+const DefaultDeferredFlushInterval = time.Second
+
+// deferredWrites contains the values buffered by PutDeferred.
+// The lock order is the map lock first, then the mu lock.
+type deferredWrites[K comparable, V any] struct {
+	mu      sync.Mutex
+	pending map[K]V
+	// used is set by the first PutDeferred call, so the maps that never buffer values skip the mu lock
+	used    atomic.Bool
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// PutDeferred buffers the specified value for the specified key, the value will be put into the map
+// by the next flush. Only the latest value buffered for a key is kept, so redundant writes are coalesced.
+// A later Put, Remove or RemoveIfExists call for the key and a later Clear or ClearIf call discard
+// the buffered value, other methods don't.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (cmap *ConcurrentMap[K, V]) PutDeferred(key K, value V) {
+	cmap.deferred.mu.Lock()
+	if cmap.deferred.pending == nil {
+		cmap.deferred.pending = make(map[K]V)
+	}
+	cmap.deferred.pending[key] = value
+	cmap.deferred.used.Store(true)
+	cmap.deferred.mu.Unlock()
+}
+
+// discardDeferredInner must be called under the write lock,
+// it discards the value buffered by PutDeferred for the key.
+func (cmap *ConcurrentMap[K, V]) discardDeferredInner(key K) {
+	if !cmap.deferred.used.Load() {
+		return
+	}
+	cmap.deferred.mu.Lock()
+	delete(cmap.deferred.pending, key)
+	cmap.deferred.mu.Unlock()
+}
+
+// discardAllDeferredInner must be called under the write lock,
+// it discards all values buffered by PutDeferred.
+func (cmap *ConcurrentMap[K, V]) discardAllDeferredInner() {
+	if !cmap.deferred.used.Load() {
+		return
+	}
+	cmap.deferred.mu.Lock()
+	cmap.deferred.pending = nil
+	cmap.deferred.mu.Unlock()
+}
+
+// Flush puts all values buffered by PutDeferred into the map.
+func (cmap *ConcurrentMap[K, V]) Flush() {
+	if !cmap.deferred.used.Load() {
+		return
+	}
+	cmap.mu.Lock()
+	cmap.deferred.mu.Lock()
+	for k, v := range cmap.deferred.pending {
+		cmap.mp[k] = v
+	}
+	cmap.deferred.pending = nil
+	cmap.deferred.mu.Unlock()
+	cmap.mu.Unlock()
+}
+
+// Close stops the background flushing of the values buffered by PutDeferred (if it was started)
+// and flushes the remaining buffered values. It is safe to call Close several times.
+func (cmap *ConcurrentMap[K, V]) Close() {
+	cmap.deferred.once.Do(func() {
+		if cmap.deferred.stop != nil {
+			close(cmap.deferred.stop)
+			<-cmap.deferred.stopped
+		}
+	})
+	cmap.Flush()
+}
+
+func (cmap *ConcurrentMap[K, V]) startFlusher(interval time.Duration) {
+	cmap.deferred.stop = make(chan struct{})
+	cmap.deferred.stopped = make(chan struct{})
+	go func() {
+		defer close(cmap.deferred.stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				cmap.Flush()
+			case <-cmap.deferred.stop:
+				return
+			}
+		}
+	}()
+}
+
+// NewConcurrentMapDeferred creates and returns a new empty ConcurrentMap instance, that flushes the values
+// buffered by PutDeferred every flushInterval in the background.
+// If flushInterval is not positive, DefaultDeferredFlushInterval is used instead.
+// The Close method should be called to stop the background flushing when the map is no longer needed.
+//   - K - comparable key type;
+//   - V - value type;
+//   - flushInterval - the interval between flushes.
+func NewConcurrentMapDeferred[K comparable, V any](flushInterval time.Duration) *ConcurrentMap[K, V] {
+	if flushInterval <= 0 {
+		flushInterval = DefaultDeferredFlushInterval
+	}
+	result := NewConcurrentMap[K, V]()
+	result.startFlusher(flushInterval)
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConcurrentMap_PutDeferred(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.PutDeferred(1, "value 1")
+	cm.PutDeferred(1, "value 2")
+	cm.PutDeferred(2, "value 3")
+	_, ok := cm.Get(1)
+	assert.False(t, ok, "the value must not be put before a flush")
+	assert.Equal(t, 0, cm.Size())
+
+	cm.Flush()
+
+	actual, ok := cm.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "value 2", actual, "the latest value must win")
+	actual, ok = cm.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "value 3", actual)
+	assert.Equal(t, 2, cm.Size())
+}
+
+func TestConcurrentMap_PutDeferred_Put(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.PutDeferred(1, "deferred")
+	cm.Put(1, "direct")
+	cm.Flush()
+	actual, ok := cm.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "direct", actual, "the later direct write must win")
+
+	cm.PutDeferred(1, "deferred")
+	cm.Flush()
+	actual, _ = cm.Get(1)
+	assert.Equal(t, "deferred", actual, "the later deferred write must win")
+}
+
+func TestConcurrentMap_PutDeferred_Remove(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "value 1")
+	cm.PutDeferred(1, "value 2")
+	cm.PutDeferred(2, "value 3")
+	cm.PutDeferred(3, "value 4")
+
+	cm.Remove(1)
+	ok, actual := cm.RemoveIfExists(2)
+	assert.False(t, ok)
+	assert.Equal(t, "", actual)
+	cm.Flush()
+
+	_, ok = cm.Get(1)
+	assert.False(t, ok, "the removed key must not come back after a flush")
+	_, ok = cm.Get(2)
+	assert.False(t, ok, "the removed key must not come back after a flush")
+	assert.Equal(t, map[int]string{3: "value 4"}, cm.Copy())
+}
+
+func TestConcurrentMap_PutDeferred_Clear(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "value 1")
+	cm.PutDeferred(2, "value 2")
+	cm.Clear()
+	cm.Flush()
+	assert.True(t, cm.IsEmpty())
+
+	cm.PutDeferred(3, "value 3")
+	assert.True(t, cm.ClearIf(func(size int) bool { return true }))
+	cm.Flush()
+	assert.True(t, cm.IsEmpty())
+}
+
+func TestNewConcurrentMapDeferred(t *testing.T) {
+	cm := NewConcurrentMapDeferred[int, string](5 * time.Millisecond)
+	defer cm.Close()
+	cm.PutDeferred(1, "value 1")
+	assert.Eventually(t, func() bool {
+		_, ok := cm.Get(1)
+		return ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestNewConcurrentMapDeferred_nonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		cm := NewConcurrentMapDeferred[int, string](interval)
+		cm.PutDeferred(1, "value 1")
+		cm.Close()
+		actual, ok := cm.Get(1)
+		assert.True(t, ok, "interval: %v", interval)
+		assert.Equal(t, "value 1", actual)
+	}
+}
+
+func TestConcurrentMap_Close(t *testing.T) {
+	cm := NewConcurrentMapDeferred[int, string](time.Hour)
+	cm.PutDeferred(1, "value 1")
+
+	cm.Close()
+
+	select {
+	case <-cm.deferred.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the flusher goroutine is still running")
+	}
+	actual, ok := cm.Get(1)
+	assert.True(t, ok, "Close must flush the buffered values")
+	assert.Equal(t, "value 1", actual)
+	cm.Close()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -435,3 +436,459 @@ func TestNewConcurrentMap(t *testing.T) {
 	}
 	t.Log("size:", size, "sum:", sum, "amount:", amount)
 }
+
+func TestContainsValue(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "value 1")
+	cm.Put(2, "value 2")
+	cm.Put(3, "value 2")
+	assert.True(t, ContainsValue(cm, "value 1"))
+	assert.True(t, ContainsValue(cm, "value 2"))
+	assert.False(t, ContainsValue(cm, "value 3"))
+	assert.False(t, ContainsValue(NewConcurrentMap[int, string](), "value 1"))
+}
+
+func TestReduceMap(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("a", 1)
+	cm.Put("bb", 2)
+	cm.Put("ccc", 3)
+	total := ReduceMap(cm, 0, func(acc int, k string, v int) int { return acc + len(k)*v })
+	assert.Equal(t, 14, total)
+	empty := ReduceMap(NewConcurrentMap[string, int](), -1, func(acc int, k string, v int) int { return acc + v })
+	assert.Equal(t, -1, empty)
+}
+
+func TestMoveEntry(t *testing.T) {
+	active := NewConcurrentMap[int, string]()
+	archived := NewConcurrentMap[int, string]()
+	active.Put(1, "value 1")
+	active.Put(2, "value 2")
+
+	value, ok := MoveEntry(active, archived, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "value 1", value)
+	_, ok = active.Get(1)
+	assert.False(t, ok)
+	value, ok = archived.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "value 1", value)
+
+	value, ok = MoveEntry(active, archived, 123)
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+	assert.Equal(t, 1, active.Size())
+	assert.Equal(t, 1, archived.Size())
+}
+
+func TestMoveEntry_concurrent(t *testing.T) {
+	const count = 1000
+	mp1 := NewConcurrentMap[int, int]()
+	mp2 := NewConcurrentMap[int, int]()
+	for i := 0; i < count; i++ {
+		mp1.Put(i, i)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < count; i++ {
+			MoveEntry(mp1, mp2, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < count; i++ {
+			MoveEntry(mp2, mp1, i)
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, count, mp1.Size()+mp2.Size())
+}
+
+func TestConcurrentMap_KeySet(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	for i := 1; i <= 5; i++ {
+		cm.Put(i, fmt.Sprint("value ", i))
+	}
+	set := cm.KeySet()
+	assert.Equal(t, cm.Size(), set.Size())
+	for _, key := range cm.Keys() {
+		assert.True(t, set.Contains(key), key)
+	}
+	assert.True(t, NewConcurrentMap[int, string]().KeySet().IsEmpty())
+}
+
+func TestValuesSet(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "active")
+	cm.Put(2, "inactive")
+	cm.Put(3, "active")
+	cm.Put(4, "active")
+	set := ValuesSet(cm)
+	assert.Equal(t, 2, set.Size())
+	assert.True(t, set.Contains("active"))
+	assert.True(t, set.Contains("inactive"))
+	assert.True(t, ValuesSet(NewConcurrentMap[int, string]()).IsEmpty())
+}
+
+func TestPageKeys(t *testing.T) {
+	const (
+		amount = 23
+		limit  = 5
+	)
+	cm := NewConcurrentMap[int, string]()
+	for i := 0; i < amount; i++ {
+		cm.Put(i*7%amount, fmt.Sprint("value ", i))
+	}
+	seen := make(map[int]int)
+	var all []int
+	for offset := 0; offset < amount; offset += limit {
+		page := PageKeys(cm, offset, limit)
+		assert.LessOrEqual(t, len(page), limit)
+		for _, key := range page {
+			seen[key]++
+		}
+		all = append(all, page...)
+	}
+	assert.Equal(t, amount, len(seen))
+	for key, count := range seen {
+		assert.Equal(t, 1, count, "key: %d", key)
+	}
+	assert.True(t, slices.IsSorted(all))
+	assert.Equal(t, []int{20, 21, 22}, PageKeys(cm, 20, limit))
+	assert.Empty(t, PageKeys(cm, amount, limit))
+	assert.Empty(t, PageKeys(cm, 0, 0))
+}
+
+func TestConcurrentMap_GetAndPut(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	old, ok := cm.GetAndPut(1, "value 1")
+	assert.False(t, ok)
+	assert.Equal(t, "", old)
+	assert.Equal(t, 1, cm.Size())
+
+	old, ok = cm.GetAndPut(1, "value 2")
+	assert.True(t, ok)
+	assert.Equal(t, "value 1", old)
+	assert.Equal(t, 1, cm.Size())
+	actual, _ := cm.Get(1)
+	assert.Equal(t, "value 2", actual)
+}
+
+func TestConcurrentMap_ClearIf(t *testing.T) {
+	cm := NewConcurrentMap[int, int]()
+	for i := 0; i < 5; i++ {
+		cm.Put(i, i)
+	}
+	overThreshold := func(size int) bool { return size > 10 }
+	assert.False(t, cm.ClearIf(overThreshold))
+	assert.Equal(t, 5, cm.Size())
+	for i := 5; i < 11; i++ {
+		cm.Put(i, i)
+	}
+	assert.True(t, cm.ClearIf(overThreshold))
+	assert.True(t, cm.IsEmpty())
+}
+
+func TestConcurrentMap_Grow(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	for i := 0; i < 10; i++ {
+		cm.Put(i, fmt.Sprint("value ", i))
+	}
+	cm.Grow(1000)
+	cm.Grow(0)
+	assert.Equal(t, 10, cm.Size())
+	for i := 0; i < 10; i++ {
+		actual, ok := cm.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, fmt.Sprint("value ", i), actual)
+	}
+}
+
+func TestConcurrentMap_GetMulti(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "one")
+	cm.Put(2, "two")
+	cm.Put(3, "three")
+	found, missing := cm.GetMulti(1, 4, 3, 5)
+	assert.Equal(t, map[int]string{1: "one", 3: "three"}, found)
+	assert.Equal(t, []int{4, 5}, missing)
+
+	found, missing = cm.GetMulti(1, 2)
+	assert.Equal(t, map[int]string{1: "one", 2: "two"}, found)
+	assert.Empty(t, missing)
+
+	found, missing = cm.GetMulti()
+	assert.Empty(t, found)
+	assert.Empty(t, missing)
+}
+
+func TestConcurrentMap_SortedEntries(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("alice", 20)
+	cm.Put("bob", 50)
+	cm.Put("carol", 10)
+	cm.Put("dave", 40)
+	actual := cm.SortedEntries(func(a, b Entry[string, int]) bool {
+		return a.Value > b.Value
+	})
+	expected := []Entry[string, int]{
+		{Key: "bob", Value: 50},
+		{Key: "dave", Value: 40},
+		{Key: "alice", Value: 20},
+		{Key: "carol", Value: 10},
+	}
+	assert.Equal(t, expected, actual)
+	assert.Empty(t, NewConcurrentMap[string, int]().SortedEntries(func(a, b Entry[string, int]) bool {
+		return a.Value > b.Value
+	}))
+}
+
+func TestDecrementAndRemoveAtZero(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("host", 2)
+	count, exists := DecrementAndRemoveAtZero(cm, "host")
+	assert.Equal(t, 1, count)
+	assert.True(t, exists)
+	actual, ok := cm.Get("host")
+	assert.True(t, ok)
+	assert.Equal(t, 1, actual)
+
+	count, exists = DecrementAndRemoveAtZero(cm, "host")
+	assert.Equal(t, 0, count)
+	assert.False(t, exists)
+	_, ok = cm.Get("host")
+	assert.False(t, ok)
+
+	count, exists = DecrementAndRemoveAtZero(cm, "missing")
+	assert.Equal(t, 0, count)
+	assert.False(t, exists)
+	assert.True(t, cm.IsEmpty())
+}
+
+func TestConcurrentMap_LoadFromChannel(t *testing.T) {
+	const count = LoadFromChannelBatchSize*2 + 10
+	ch := make(chan Entry[int, string], count)
+	for i := 0; i < count; i++ {
+		ch <- Entry[int, string]{Key: i, Value: fmt.Sprint("value ", i)}
+	}
+	close(ch)
+	cm := NewConcurrentMap[int, string]()
+	cm.LoadFromChannel(ch)
+	assert.Equal(t, count, cm.Size())
+	for i := 0; i < count; i++ {
+		actual, ok := cm.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, fmt.Sprint("value ", i), actual)
+	}
+}
+
+func TestConcurrentMap_MissingKeys(t *testing.T) {
+	cm := NewConcurrentMap[string, string]()
+	cm.Put("host", "localhost")
+	cm.Put("port", "8080")
+	cm.Put("debug", "true")
+	missing := cm.MissingKeys(NewConcurrentSetWithValues[string]("host", "user", "port", "password"))
+	slices.Sort(missing)
+	assert.Equal(t, []string{"password", "user"}, missing)
+	assert.Empty(t, cm.MissingKeys(NewConcurrentSetWithValues[string]("host", "port")))
+	assert.Empty(t, cm.MissingKeys(NewConcurrentSet[string]()))
+}
+
+func TestConcurrentMap_TryGet(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "one")
+	actual, found, locked := cm.TryGet(1)
+	assert.True(t, locked)
+	assert.True(t, found)
+	assert.Equal(t, "one", actual)
+	_, found, locked = cm.TryGet(2)
+	assert.True(t, locked)
+	assert.False(t, found)
+
+	held := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		cm.ForEach(func(key int, value string) {
+			close(held)
+			<-release
+		})
+	}()
+	<-held
+	actual, found, locked = cm.TryGet(1)
+	close(release)
+	assert.False(t, locked)
+	assert.False(t, found)
+	assert.Equal(t, "", actual)
+}
+
+func TestConcurrentMap_CopyInto(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	cm.Put(1, "one")
+	cm.Put(2, "two")
+	dst := make(map[int]string)
+	cm.CopyInto(dst)
+	assert.Equal(t, map[int]string{1: "one", 2: "two"}, dst)
+
+	cm.Remove(1)
+	cm.Put(3, "three")
+	cm.CopyInto(dst)
+	assert.Equal(t, map[int]string{2: "two", 3: "three"}, dst)
+	assert.Equal(t, cm.Copy(), dst)
+
+	assert.NotPanics(t, func() { NewConcurrentMap[int, string]().CopyInto(nil) })
+	assert.Panics(t, func() { cm.CopyInto(nil) }, "a nil destination can't be filled")
+	cm.Put(4, "four")
+	assert.Equal(t, 3, cm.Size(), "the lock must be released after the panic")
+}
+
+func TestConcurrentMap_StableForEach(t *testing.T) {
+	cm := NewConcurrentMap[int, string]()
+	for _, key := range []int{5, 3, 9, 1, 7} {
+		cm.Put(key, fmt.Sprint("value ", key))
+	}
+	keys := make([]int, 0, cm.Size())
+	cm.StableForEach(func(a, b int) bool { return a < b }, func(key int, value string) {
+		assert.Equal(t, fmt.Sprint("value ", key), value)
+		keys = append(keys, key)
+		cm.Put(key+100, value)
+	})
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, keys)
+	assert.Equal(t, 10, cm.Size())
+}
+
+func TestConcurrentMap_GetOrPutDefault(t *testing.T) {
+	cm := NewConcurrentMap[string, []int]()
+	actual := cm.GetOrPutDefault("absent", []int{1})
+	assert.Equal(t, []int{1}, actual)
+	stored, ok := cm.Get("absent")
+	assert.True(t, ok)
+	assert.Equal(t, []int{1}, stored)
+
+	cm.Put("present", []int{2, 3})
+	assert.Equal(t, []int{2, 3}, cm.GetOrPutDefault("present", []int{4}))
+	assert.Equal(t, []int{1}, cm.GetOrPutDefault("absent", nil))
+	assert.Equal(t, 2, cm.Size())
+}
+
+func TestConcurrentMap_ForEachParallel(t *testing.T) {
+	const count = 1000
+	cm := NewConcurrentMapCapacity[int, int](count)
+	expected := int64(0)
+	for i := 0; i < count; i++ {
+		cm.Put(i, i*2)
+		expected += int64(i * 2)
+	}
+	for _, workers := range []int{-1, 1, 4, count * 2} {
+		var sum, calls atomic.Int64
+		cm.ForEachParallel(workers, func(key int, value int) {
+			sum.Add(int64(value))
+			calls.Add(1)
+			_, _ = cm.Get(key)
+		})
+		assert.Equal(t, expected, sum.Load(), "workers: %d", workers)
+		assert.Equal(t, int64(count), calls.Load(), "workers: %d", workers)
+	}
+	NewConcurrentMap[int, int]().ForEachParallel(4, func(key int, value int) { t.Fail() })
+}
+
+func TestConcurrentMap_GetOrCompute(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("present", 1)
+	actual, computed := cm.GetOrCompute("present", func() int {
+		t.Fatal("the value should not be computed for an existing key")
+		return 0
+	})
+	assert.False(t, computed)
+	assert.Equal(t, 1, actual)
+
+	actual, computed = cm.GetOrCompute("absent", func() int { return 2 })
+	assert.True(t, computed)
+	assert.Equal(t, 2, actual)
+	stored, ok := cm.Get("absent")
+	assert.True(t, ok)
+	assert.Equal(t, 2, stored)
+}
+
+func TestConcurrentMap_GetOrCompute_concurrent(t *testing.T) {
+	const threads = 100
+	cm := NewConcurrentMap[string, int]()
+	var computations, fresh atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func() {
+			defer wg.Done()
+			val, computed := cm.GetOrCompute("key", func() int {
+				return int(computations.Add(1))
+			})
+			assert.Equal(t, 1, val)
+			if computed {
+				fresh.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), computations.Load())
+	assert.Equal(t, int32(1), fresh.Load())
+}
+
+func TestConcurrentMap_Values(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	assert.Empty(t, cm.Values())
+	cm.Put("one", 1)
+	cm.Put("two", 2)
+	cm.Put("another one", 1)
+	actual := cm.Values()
+	assert.Equal(t, cm.Size(), len(actual))
+	assert.ElementsMatch(t, []int{1, 1, 2}, actual)
+}
+
+func TestConcurrentMap_Compute(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	increment := func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	}
+	actual, ok := cm.Compute("counter", increment)
+	assert.True(t, ok)
+	assert.Equal(t, 1, actual)
+	actual, ok = cm.Compute("counter", increment)
+	assert.True(t, ok)
+	assert.Equal(t, 2, actual)
+
+	actual, ok = cm.Compute("counter", func(old int, exists bool) (int, bool) {
+		assert.True(t, exists)
+		return old, old < 2
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 0, actual)
+	_, ok = cm.Get("counter")
+	assert.False(t, ok)
+
+	actual, ok = cm.Compute("missing", func(old int, exists bool) (int, bool) {
+		assert.False(t, exists)
+		return 0, false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 0, actual)
+	assert.True(t, cm.IsEmpty())
+}
+
+func TestConcurrentMap_Compute_concurrent(t *testing.T) {
+	const threads = 100
+	cm := NewConcurrentMap[string, int]()
+	var wg sync.WaitGroup
+	wg.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func() {
+			defer wg.Done()
+			cm.Compute("counter", func(old int, exists bool) (int, bool) {
+				return old + 1, true
+			})
+		}()
+	}
+	wg.Wait()
+	actual, _ := cm.Get("counter")
+	assert.Equal(t, threads, actual)
+}
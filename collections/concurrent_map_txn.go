@@ -0,0 +1,73 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+// Txn is a transaction over a ConcurrentMap, it is passed to the function of the ConcurrentMap.Transaction method.
+// All the changes made through Txn are buffered and applied to the map only if the transaction succeeds.
+//   - K - comparable key type
+//   - V - value type
+type Txn[K comparable, V any] struct {
+	mp     map[K]V
+	staged map[K]txnWrite[V]
+}
+
+type txnWrite[V any] struct {
+	value   V
+	deleted bool
+}
+
+// Get returns the value to which the specified key is mapped and the sign of existence of this value,
+// taking into account the changes made in this transaction.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (tx Txn[K, V]) Get(key K) (V, bool) {
+	if w, ok := tx.staged[key]; ok {
+		if w.deleted {
+			var res V
+			return res, false
+		}
+		return w.value, true
+	}
+	val, ok := tx.mp[key]
+	return val, ok
+} //revive:enable:confusing-naming
+
+// Put maps the specified key to the specified value within this transaction.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+func (tx Txn[K, V]) Put(key K, value V) {
+	tx.staged[key] = txnWrite[V]{value: value}
+}
+
+// Delete removes the key and its corresponding value within this transaction.
+//   - key - the key that needs to be removed
+func (tx Txn[K, V]) Delete(key K) {
+	tx.staged[key] = txnWrite[V]{deleted: true}
+}
+
+// Transaction performs the function f as an all-or-nothing update of this map.
+// The changes made through the Txn are applied to the map only if f returns nil,
+// otherwise none of them are applied and the error returned by f is returned.
+// The write lock is held during the whole call, so the map can't be changed by other goroutines during it.
+//   - f - the function that reads and changes the map through the transaction
+//
+// Note! Do NOT USE ConcurrentMap methods inside the 'f' function, as this will cause a deadlock.
+func (cmap *ConcurrentMap[K, V]) Transaction(f func(tx Txn[K, V]) error) error {
+	cmap.mu.Lock()
+	defer cmap.mu.Unlock()
+	tx := Txn[K, V]{mp: cmap.mp, staged: make(map[K]txnWrite[V])}
+	if err := f(tx); err != nil {
+		return err
+	}
+	for k, w := range tx.staged {
+		if w.deleted {
+			delete(cmap.mp, k)
+		} else {
+			cmap.mp[k] = w.value
+		}
+	}
+	return nil
+}
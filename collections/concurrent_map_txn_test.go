@@ -0,0 +1,48 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConcurrentMap_Transaction(t *testing.T) {
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("alice", 100)
+	cm.Put("bob", 50)
+	cm.Put("carol", 10)
+	err := cm.Transaction(func(tx Txn[string, int]) error {
+		alice, _ := tx.Get("alice")
+		bob, _ := tx.Get("bob")
+		tx.Put("alice", alice-30)
+		tx.Put("bob", bob+30)
+		tx.Delete("carol")
+		actual, ok := tx.Get("alice")
+		assert.True(t, ok)
+		assert.Equal(t, 70, actual)
+		_, ok = tx.Get("carol")
+		assert.False(t, ok)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"alice": 70, "bob": 80}, cm.Copy())
+}
+
+func TestConcurrentMap_Transaction_rollback(t *testing.T) {
+	errInsufficient := errors.New("insufficient funds")
+	cm := NewConcurrentMap[string, int]()
+	cm.Put("alice", 100)
+	cm.Put("bob", 50)
+	err := cm.Transaction(func(tx Txn[string, int]) error {
+		tx.Put("bob", 200)
+		tx.Delete("alice")
+		tx.Put("carol", 1)
+		return errInsufficient
+	})
+	assert.ErrorIs(t, err, errInsufficient)
+	assert.Equal(t, map[string]int{"alice": 100, "bob": 50}, cm.Copy())
+}
@@ -0,0 +1,137 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import "sync"
+
+// ConcurrentOrderedSet is a thread safe set that preserves the insertion order of its values.
+// ConcurrentOrderedSet is safe for concurrent use by multiple goroutines.
+//   - T - value type
+type ConcurrentOrderedSet[T comparable] struct {
+	mu    sync.RWMutex
+	mp    map[T]*listItem[T]
+	first *listItem[T]
+	last  *listItem[T]
+}
+
+// ForEach performs a given action for each value of the ConcurrentOrderedSet in the insertion order
+//   - f - the function, that will be called for each value in ConcurrentOrderedSet
+//
+// It should not be used to modify values if the value type (T) is a reference type,
+// because a read lock is used under the hood.
+// Note! ConcurrentOrderedSet methods, such as Contains and Size can be used inside the 'f' function.
+// However, you should not use methods that modify ConcurrentOrderedSet, as this will cause a deadlock.
+//
+//revive:disable:confusing-naming
+func (oset *ConcurrentOrderedSet[T]) ForEach(f func(value T)) {
+	oset.mu.RLock()
+	for item := oset.first; item != nil; item = item.next {
+		f(item.value)
+	}
+	oset.mu.RUnlock()
+} //revive:enable:confusing-naming
+
+// Add adds a specified value to the end of the set.
+// Returns true if the value did not exist and was added to the set, otherwise returns false,
+// in this case the position of the value is not changed.
+func (oset *ConcurrentOrderedSet[T]) Add(value T) bool {
+	oset.mu.Lock()
+	defer oset.mu.Unlock()
+	if _, ok := oset.mp[value]; ok {
+		return false
+	}
+	item := &listItem[T]{value: value}
+	if oset.last != nil {
+		oset.last.append(item)
+	} else {
+		oset.first = item
+	}
+	oset.last = item
+	oset.mp[value] = item
+	return true
+}
+
+// Remove removes a value from the set.
+// Returns true if this ConcurrentOrderedSet changed as result of the call.
+//
+//revive:disable:confusing-naming
+func (oset *ConcurrentOrderedSet[T]) Remove(value T) bool {
+	oset.mu.Lock()
+	defer oset.mu.Unlock()
+	item, ok := oset.mp[value]
+	if !ok {
+		return false
+	}
+	item.removeYourself()
+	if oset.first == item {
+		oset.first = item.next
+	}
+	if oset.last == item {
+		oset.last = item.prev
+	}
+	item.prev = nil
+	item.next = nil
+	delete(oset.mp, value)
+	return true
+} //revive:enable:confusing-naming
+
+// Contains returns true if the set contains the value
+func (oset *ConcurrentOrderedSet[T]) Contains(value T) bool {
+	oset.mu.RLock()
+	_, res := oset.mp[value]
+	oset.mu.RUnlock()
+	return res
+}
+
+// Clear clears the set.
+//
+//revive:disable:confusing-naming
+func (oset *ConcurrentOrderedSet[T]) Clear() {
+	oset.mu.Lock()
+	oset.mp = make(map[T]*listItem[T])
+	oset.first = nil
+	oset.last = nil
+	oset.mu.Unlock()
+} //revive:enable:confusing-naming
+
+// Size returns the current size of the ConcurrentOrderedSet.
+//
+//revive:disable:confusing-naming
+func (oset *ConcurrentOrderedSet[T]) Size() int {
+	oset.mu.RLock()
+	defer oset.mu.RUnlock()
+	return len(oset.mp)
+} //revive:enable:confusing-naming
+
+// IsEmpty returns true if the ConcurrentOrderedSet does not contain any values
+//
+//revive:disable:confusing-naming
+func (oset *ConcurrentOrderedSet[T]) IsEmpty() bool {
+	oset.mu.RLock()
+	defer oset.mu.RUnlock()
+	return len(oset.mp) == 0
+} //revive:enable:confusing-naming
+
+// ToSlice returns a slice of ConcurrentOrderedSet elements in the insertion order
+func (oset *ConcurrentOrderedSet[T]) ToSlice() []T {
+	oset.mu.RLock()
+	result := make([]T, 0, len(oset.mp))
+	for item := oset.first; item != nil; item = item.next {
+		result = append(result, item.value)
+	}
+	oset.mu.RUnlock()
+	return result
+}
+
+// NewConcurrentOrderedSet returns a new ConcurrentOrderedSet instance containing specified values
+// in the specified order
+//   - values ...T - values that the ConcurrentOrderedSet will contain
+func NewConcurrentOrderedSet[T comparable](values ...T) *ConcurrentOrderedSet[T] {
+	result := &ConcurrentOrderedSet[T]{mp: make(map[T]*listItem[T], len(values))}
+	for _, value := range values {
+		result.Add(value)
+	}
+	return result
+}
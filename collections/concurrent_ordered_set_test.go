@@ -0,0 +1,54 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConcurrentOrderedSet_order(t *testing.T) {
+	set := NewConcurrentOrderedSet[int](5, 3, 1)
+	assert.True(t, set.Add(4))
+	assert.False(t, set.Add(5), "the existing value must not be moved")
+	assert.True(t, set.Add(2))
+	assert.Equal(t, []int{5, 3, 1, 4, 2}, set.ToSlice())
+
+	assert.True(t, set.Remove(5))
+	assert.True(t, set.Remove(1))
+	assert.True(t, set.Remove(2))
+	assert.False(t, set.Remove(2))
+	assert.True(t, set.Add(5))
+	assert.Equal(t, []int{3, 4, 5}, set.ToSlice())
+	assert.Equal(t, 3, set.Size())
+
+	var visited []int
+	set.ForEach(func(value int) {
+		visited = append(visited, value)
+		assert.True(t, set.Contains(value))
+	})
+	assert.Equal(t, []int{3, 4, 5}, visited)
+}
+
+func TestConcurrentOrderedSet_Remove_all(t *testing.T) {
+	set := NewConcurrentOrderedSet[string]("a", "b")
+	assert.True(t, set.Remove("a"))
+	assert.True(t, set.Remove("b"))
+	assert.True(t, set.IsEmpty())
+	assert.Nil(t, set.first)
+	assert.Nil(t, set.last)
+	assert.True(t, set.Add("c"))
+	assert.Equal(t, []string{"c"}, set.ToSlice())
+}
+
+func TestConcurrentOrderedSet_Clear(t *testing.T) {
+	set := NewConcurrentOrderedSet[int](1, 2, 3)
+	set.Clear()
+	assert.True(t, set.IsEmpty())
+	assert.Empty(t, set.ToSlice())
+	assert.False(t, set.Contains(1))
+	set.Add(2)
+	assert.Equal(t, []int{2}, set.ToSlice())
+}
@@ -4,15 +4,20 @@
 
 package collections
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // ConcurrentSet is a thread safe set.
 // ConcurrentSet is safe for concurrent use by multiple goroutines.
 //   - T - value type
 type ConcurrentSet[T comparable] struct {
-	mu       sync.RWMutex
-	mp       map[T]struct{}
-	capacity int
+	mu          sync.RWMutex
+	mp          map[T]struct{}
+	capacity    int
+	subscribers setSubscribers[T]
+	adds        atomic.Uint64
 }
 
 // ForEach performs a given action for each value of the ConcurrentSet
@@ -20,6 +25,9 @@ type ConcurrentSet[T comparable] struct {
 //
 // It should not be used to modify values if the value type (T) is a reference type,
 // because a read lock is used under the hood.
+// Note! ConcurrentSet methods, such as Contains and Size can be used inside the 'f' function.
+// However, you should not use methods that modify ConcurrentSet, as this will cause a deadlock,
+// use ForEachMutate to remove values during iteration.
 //
 //revive:disable:confusing-naming
 func (cset *ConcurrentSet[T]) ForEach(f func(value T)) {
@@ -30,31 +38,141 @@ func (cset *ConcurrentSet[T]) ForEach(f func(value T)) {
 	cset.mu.RUnlock()
 } //revive:enable:confusing-naming
 
+// ForEachParallel performs a given action for each value of the ConcurrentSet using the specified number
+// of goroutines and waits for all of them to complete. The values are processed in an unspecified order.
+// The values are copied under the read lock and processed after the lock is released,
+// so ConcurrentSet methods can be used inside the 'f' function.
+//   - workers - the number of goroutines, a value less than 1 is treated as 1
+//   - f - the function, that will be called for each value in ConcurrentSet
+//
+// Note! The 'f' function is called concurrently, so it must be safe for concurrent use.
+func (cset *ConcurrentSet[T]) ForEachParallel(workers int, f func(value T)) {
+	forEachParallel(cset.ToSlice(), workers, f)
+}
+
+// ForEachMutate performs a given action for each value of the ConcurrentSet
+// and removes the values for which the action returns false.
+//   - f - the function, that will be called for each value in ConcurrentSet,
+//     it returns true if the value should be kept in the set
+//
+// Note! Do NOT USE ConcurrentSet methods inside the 'f' function, as this will cause a deadlock.
+func (cset *ConcurrentSet[T]) ForEachMutate(f func(value T) (keep bool)) {
+	var removed []T
+	cset.mu.Lock()
+	for k := range cset.mp {
+		if !f(k) {
+			delete(cset.mp, k)
+			removed = append(removed, k)
+		}
+	}
+	cset.mu.Unlock()
+	cset.subscribers.publish(false, removed...)
+}
+
 // AddAll adds all the specified values to the ConcurrentSet.
 // Returns true if this ConcurrentSet changed as result of the call.
 func (cset *ConcurrentSet[T]) AddAll(values ...T) bool {
-	changed := false
+	if cset.subscribers.active() {
+		return len(cset.GetOrAddAll(values...)) > 0
+	}
+	added := 0
+	cset.mu.Lock()
+	for _, value := range values {
+		if _, ok := cset.mp[value]; !ok {
+			cset.mp[value] = struct{}{}
+			added++
+		}
+	}
+	cset.mu.Unlock()
+	cset.adds.Add(uint64(added))
+	return added > 0
+}
+
+// AddAllStrict adds all the specified values to the ConcurrentSet only if none of them is already contained
+// in the set or duplicated within the values (all-or-nothing).
+// Returns the number of added values, the zero value of type T and true if all the values were added,
+// otherwise the set is not changed and 0, the first conflicting value and false are returned.
+func (cset *ConcurrentSet[T]) AddAllStrict(values ...T) (added int, firstDuplicate T, ok bool) {
+	batch := make(map[T]struct{}, len(values))
+	cset.mu.Lock()
+	for _, value := range values {
+		_, exists := cset.mp[value]
+		if !exists {
+			_, exists = batch[value]
+		}
+		if exists {
+			cset.mu.Unlock()
+			return 0, value, false
+		}
+		batch[value] = struct{}{}
+	}
+	for _, value := range values {
+		cset.mp[value] = struct{}{}
+	}
+	cset.mu.Unlock()
+	cset.adds.Add(uint64(len(values)))
+	cset.subscribers.publish(true, values...)
+	return len(values), firstDuplicate, true
+}
+
+// GetOrAddAll adds all the specified values to the ConcurrentSet.
+// Returns the values that did not exist and were added to the set in the order they were specified.
+func (cset *ConcurrentSet[T]) GetOrAddAll(values ...T) (added []T) {
+	added = make([]T, 0, len(values))
 	cset.mu.Lock()
 	for _, value := range values {
 		if _, ok := cset.mp[value]; !ok {
 			cset.mp[value] = struct{}{}
-			changed = true
+			added = append(added, value)
 		}
 	}
 	cset.mu.Unlock()
-	return changed
+	cset.adds.Add(uint64(len(added)))
+	cset.subscribers.publish(true, added...)
+	return added
 }
 
 // Add adds a specified value to the set.
 // Returns true if the value did not exist and was added to the set, otherwise returns false.
 func (cset *ConcurrentSet[T]) Add(value T) bool {
 	cset.mu.Lock()
-	defer cset.mu.Unlock()
-	if _, ok := cset.mp[value]; !ok {
+	_, exists := cset.mp[value]
+	if !exists {
+		cset.mp[value] = struct{}{}
+	}
+	cset.mu.Unlock()
+	if !exists {
+		cset.adds.Add(1)
+		cset.subscribers.publish(true, value)
+	}
+	return !exists
+}
+
+// AddIfSizeBelow adds a specified value to the set only if the current size of the set is less than limit.
+// Returns true if the value was added to the set and the size of the set after the call.
+// If the set already contains the value, it is not changed and false is returned.
+//   - value - the value to be added
+//   - limit - the size limit of the set
+func (cset *ConcurrentSet[T]) AddIfSizeBelow(value T, limit int) (added bool, size int) {
+	cset.mu.Lock()
+	if _, ok := cset.mp[value]; !ok && len(cset.mp) < limit {
 		cset.mp[value] = struct{}{}
-		return true
+		added = true
+	}
+	size = len(cset.mp)
+	cset.mu.Unlock()
+	if added {
+		cset.adds.Add(1)
+		cset.subscribers.publish(true, value)
 	}
-	return false
+	return added, size
+}
+
+// AddCount returns the total number of the values that were added to the ConcurrentSet since its creation,
+// including the values that were removed later. Adding a value that already exists is not counted.
+// Unlike Size, it can be used as a throughput metric.
+func (cset *ConcurrentSet[T]) AddCount() uint64 {
+	return cset.adds.Load()
 }
 
 // Remove removes a value from the set.
@@ -63,14 +181,35 @@ func (cset *ConcurrentSet[T]) Add(value T) bool {
 //revive:disable:confusing-naming
 func (cset *ConcurrentSet[T]) Remove(value T) bool {
 	cset.mu.Lock()
-	defer cset.mu.Unlock()
-	if _, ok := cset.mp[value]; ok {
+	_, exists := cset.mp[value]
+	if exists {
 		delete(cset.mp, value)
-		return true
 	}
-	return false
+	cset.mu.Unlock()
+	if exists {
+		cset.subscribers.publish(false, value)
+	}
+	return exists
 } //revive:enable:confusing-naming
 
+// DifferenceUpdate removes from this set all the values that are contained in the other set.
+// Returns the number of removed values.
+//   - other - the set whose values are to be removed from this set
+func (cset *ConcurrentSet[T]) DifferenceUpdate(other *ConcurrentSet[T]) int {
+	values := other.ToSlice()
+	removed := values[:0]
+	cset.mu.Lock()
+	for _, value := range values {
+		if _, ok := cset.mp[value]; ok {
+			delete(cset.mp, value)
+			removed = append(removed, value)
+		}
+	}
+	cset.mu.Unlock()
+	cset.subscribers.publish(false, removed...)
+	return len(removed)
+}
+
 // Contains returns true if the set contains the value
 func (cset *ConcurrentSet[T]) Contains(value T) bool {
 	cset.mu.RLock()
@@ -79,6 +218,32 @@ func (cset *ConcurrentSet[T]) Contains(value T) bool {
 	return res
 }
 
+// FilterPresent returns the candidates that are contained in the ConcurrentSet, preserving their order.
+// All the candidates are checked under a single read lock.
+//   - candidates - the values to be checked
+func (cset *ConcurrentSet[T]) FilterPresent(candidates []T) []T {
+	return cset.filter(candidates, true)
+}
+
+// FilterAbsent returns the candidates that aren't contained in the ConcurrentSet, preserving their order.
+// It is the complement of FilterPresent. All the candidates are checked under a single read lock.
+//   - candidates - the values to be checked
+func (cset *ConcurrentSet[T]) FilterAbsent(candidates []T) []T {
+	return cset.filter(candidates, false)
+}
+
+func (cset *ConcurrentSet[T]) filter(candidates []T, present bool) []T {
+	result := make([]T, 0, len(candidates))
+	cset.mu.RLock()
+	for _, value := range candidates {
+		if _, ok := cset.mp[value]; ok == present {
+			result = append(result, value)
+		}
+	}
+	cset.mu.RUnlock()
+	return result
+}
+
 // TrimToSize trims the capacity of this ConcurrentSet instance to be the set's current size.
 // An application can use this operation to minimize the storage of a ConcurrentSet instance.
 func (cset *ConcurrentSet[T]) TrimToSize() {
@@ -94,12 +259,30 @@ func (cset *ConcurrentSet[T]) TrimToSize() {
 // Clear clears the set.
 func (cset *ConcurrentSet[T]) Clear() {
 	cset.mu.Lock()
+	old := cset.swapInner()
+	cset.mu.Unlock()
+	cset.publishRemovedAll(old)
+}
+
+func (cset *ConcurrentSet[T]) swapInner() map[T]struct{} {
+	old := cset.mp
 	if cset.capacity > 0 {
 		cset.mp = make(map[T]struct{}, cset.capacity)
 	} else {
 		cset.mp = make(map[T]struct{})
 	}
-	cset.mu.Unlock()
+	return old
+}
+
+func (cset *ConcurrentSet[T]) publishRemovedAll(removed map[T]struct{}) {
+	if !cset.subscribers.active() {
+		return
+	}
+	values := make([]T, 0, len(removed))
+	for k := range removed {
+		values = append(values, k)
+	}
+	cset.subscribers.publish(false, values...)
 }
 
 // Size returns the current size of the ConcurrentSet.
@@ -127,6 +310,33 @@ func (cset *ConcurrentSet[T]) ToSlice() []T {
 	return result
 }
 
+// ToLinkedList returns a new ConcurrentLinkedList containing the ConcurrentSet elements in an unspecified order
+func (cset *ConcurrentSet[T]) ToLinkedList() *ConcurrentLinkedList[T] {
+	result := NewConcurrentLinkedList[T]()
+	cset.mu.RLock()
+	for k := range cset.mp {
+		result.addLastInner(&listItem[T]{value: k})
+	}
+	cset.mu.RUnlock()
+	return result
+}
+
+// DrainToList atomically removes all the values from the ConcurrentSet
+// and returns them as a new ConcurrentLinkedList in an unspecified order.
+// Only the swap of the underlying map is performed under the write lock,
+// the list is built from the former contents outside the lock.
+func (cset *ConcurrentSet[T]) DrainToList() *ConcurrentLinkedList[T] {
+	cset.mu.Lock()
+	old := cset.swapInner()
+	cset.mu.Unlock()
+	cset.publishRemovedAll(old)
+	result := NewConcurrentLinkedList[T]()
+	for k := range old {
+		result.addLastInner(&listItem[T]{value: k})
+	}
+	return result
+}
+
 // NewConcurrentSet returns a new empty ConcurrentSet instance
 //   - T - value type
 func NewConcurrentSet[T comparable]() *ConcurrentSet[T] {
@@ -147,3 +357,34 @@ func NewConcurrentSetWithValues[T comparable](values ...T) *ConcurrentSet[T] {
 	result.AddAll(values...)
 	return result
 }
+
+// ReduceSet applies the function f to each element of the set, passing the result of the previous call
+// as the accumulator. Returns the final value of the accumulator or initial if the set is empty.
+// The elements are visited in an unspecified order.
+//   - cset - the set to be reduced
+//   - initial - the initial value of the accumulator
+//   - f - the function that combines the accumulator with an element of the set
+func ReduceSet[T comparable, A any](cset *ConcurrentSet[T], initial A, f func(acc A, value T) A) A {
+	acc := initial
+	cset.mu.RLock()
+	for value := range cset.mp {
+		acc = f(acc, value)
+	}
+	cset.mu.RUnlock()
+	return acc
+}
+
+// MapSet returns a new set containing the results of applying the function f to each element of the set.
+// The elements of the source set are snapshotted under the read lock, so f is called without holding any lock.
+// Note! If f maps different elements to the same value, the resulting set contains this value only once,
+// so the result may be smaller than the source set.
+//   - cset - the source set
+//   - f - the mapping function
+func MapSet[T comparable, R comparable](cset *ConcurrentSet[T], f func(value T) R) *ConcurrentSet[R] {
+	values := cset.ToSlice()
+	result := NewConcurrentSetCapacity[R](len(values))
+	for _, value := range values {
+		result.mp[f(value)] = struct{}{}
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SetChangeBufferSize is the buffer size of the channels returned by ConcurrentSet.Subscribe
+const SetChangeBufferSize = 64
+
+// SetChange is a change of a ConcurrentSet delivered to its subscribers.
+//   - T - value type
+type SetChange[T comparable] struct {
+	// Value is the added or removed value
+	Value T
+	// Added is true if the value was added to the set and false if it was removed from the set
+	Added bool
+}
+
+type setSubscribers[T comparable] struct {
+	mu    sync.Mutex
+	chans map[chan SetChange[T]]struct{}
+	count atomic.Int32
+}
+
+func (subs *setSubscribers[T]) active() bool {
+	return subs.count.Load() > 0
+}
+
+func (subs *setSubscribers[T]) publish(added bool, values ...T) {
+	if len(values) == 0 || !subs.active() {
+		return
+	}
+	subs.mu.Lock()
+	for ch := range subs.chans {
+		for _, value := range values {
+			select {
+			case ch <- SetChange[T]{Value: value, Added: added}:
+			default:
+				// the subscriber is too slow, the change is dropped
+			}
+		}
+	}
+	subs.mu.Unlock()
+}
+
+// Subscribe returns a channel of the changes of the ConcurrentSet and a function that cancels the subscription.
+// A change is sent to the channel after each value that was added to or removed from the set.
+// The changes are sent outside the lock, so the changes made concurrently by different goroutines
+// may be received in a different order than they were applied.
+// The channel is buffered (see SetChangeBufferSize), if its buffer is full,
+// the changes are dropped so that a slow subscriber never blocks the set.
+// The unsubscribe function closes the channel, it can be called more than once.
+func (cset *ConcurrentSet[T]) Subscribe() (<-chan SetChange[T], func()) {
+	ch := make(chan SetChange[T], SetChangeBufferSize)
+	subs := &cset.subscribers
+	subs.mu.Lock()
+	if subs.chans == nil {
+		subs.chans = make(map[chan SetChange[T]]struct{})
+	}
+	subs.chans[ch] = struct{}{}
+	subs.count.Add(1)
+	subs.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			subs.mu.Lock()
+			delete(subs.chans, ch)
+			subs.count.Add(-1)
+			close(ch)
+			subs.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConcurrentSet_Subscribe(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1)
+	changes, unsubscribe := set.Subscribe()
+	set.Add(2)
+	set.Add(2)
+	set.Remove(1)
+	set.Remove(3)
+	set.GetOrAddAll(3, 4)
+	set.AddAll(4, 5)
+	set.DifferenceUpdate(NewConcurrentSetWithValues[int](3, 6))
+	expected := []SetChange[int]{
+		{Value: 2, Added: true},
+		{Value: 1, Added: false},
+		{Value: 3, Added: true},
+		{Value: 4, Added: true},
+		{Value: 5, Added: true},
+		{Value: 3, Added: false},
+	}
+	actual := make([]SetChange[int], 0, len(expected))
+	for len(actual) < len(expected) {
+		actual = append(actual, <-changes)
+	}
+	assert.Equal(t, expected, actual)
+	assert.Empty(t, changes)
+
+	set.Clear()
+	removed := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		change := <-changes
+		assert.False(t, change.Added)
+		removed = append(removed, change.Value)
+	}
+	assert.ElementsMatch(t, []int{2, 4, 5}, removed)
+
+	unsubscribe()
+	unsubscribe()
+	set.Add(7)
+	_, ok := <-changes
+	assert.False(t, ok, "the channel should be closed")
+	assert.True(t, set.Contains(7))
+}
+
+func TestConcurrentSet_Subscribe_slowSubscriber(t *testing.T) {
+	set := NewConcurrentSet[int]()
+	changes, unsubscribe := set.Subscribe()
+	defer unsubscribe()
+	for i := 0; i < SetChangeBufferSize*2; i++ {
+		set.Add(i)
+	}
+	assert.Equal(t, SetChangeBufferSize*2, set.Size())
+	assert.Equal(t, SetChangeBufferSize, len(changes))
+	for i := 0; i < SetChangeBufferSize; i++ {
+		assert.Equal(t, SetChange[int]{Value: i, Added: true}, <-changes)
+	}
+}
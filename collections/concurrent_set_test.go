@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -279,3 +280,235 @@ func TestConcurrentSet(t *testing.T) {
 		t.Fatalf("incorrect sum: %d, want: %d", sum, count)
 	}
 }
+
+func TestConcurrentSet_AddIfSizeBelow(t *testing.T) {
+	const limit = 3
+	set := NewConcurrentSet[int]()
+	for i := 1; i <= limit; i++ {
+		added, size := set.AddIfSizeBelow(i, limit)
+		assert.True(t, added)
+		assert.Equal(t, i, size)
+	}
+	added, size := set.AddIfSizeBelow(4, limit)
+	assert.False(t, added)
+	assert.Equal(t, limit, size)
+	assert.False(t, set.Contains(4))
+
+	set.Remove(3)
+	added, size = set.AddIfSizeBelow(1, limit)
+	assert.False(t, added, "the value already exists")
+	assert.Equal(t, 2, size)
+	added, size = set.AddIfSizeBelow(4, limit)
+	assert.True(t, added)
+	assert.Equal(t, limit, size)
+}
+
+func TestConcurrentSet_DifferenceUpdate(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3, 4, 5)
+	other := NewConcurrentSetWithValues[int](2, 4, 6, 8)
+	actual := set.DifferenceUpdate(other)
+	assert.Equal(t, 2, actual)
+	values := set.ToSlice()
+	slices.Sort(values)
+	assert.Equal(t, []int{1, 3, 5}, values)
+	assert.Equal(t, 4, other.Size(), "the other set must not be changed")
+
+	assert.Equal(t, 3, set.DifferenceUpdate(set))
+	assert.True(t, set.IsEmpty())
+}
+
+func TestReduceSet(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3, 4)
+	product := ReduceSet(set, 1, func(acc int, value int) int { return acc * value })
+	assert.Equal(t, 24, product)
+	count := ReduceSet(set, 0, func(acc int, value int) int { return acc + 1 })
+	assert.Equal(t, set.Size(), count)
+	empty := ReduceSet(NewConcurrentSet[int](), 123, func(acc int, value int) int { return acc + value })
+	assert.Equal(t, 123, empty)
+}
+
+func TestConcurrentSet_ToLinkedList(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3, 4)
+	list := set.ToLinkedList()
+	assert.Equal(t, set.Size(), list.Size())
+	actual := list.ToArray()
+	slices.Sort(actual)
+	assert.Equal(t, []int{1, 2, 3, 4}, actual)
+	assert.True(t, NewConcurrentSet[int]().ToLinkedList().IsEmpty())
+}
+
+func TestConcurrentSet_ForEach_read_methods(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3)
+	set.ForEach(func(value int) {
+		assert.True(t, set.Contains(value))
+		assert.Equal(t, 3, set.Size())
+	})
+}
+
+func TestConcurrentSet_ForEachMutate(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3, 4, 5, 6)
+	visited := 0
+	set.ForEachMutate(func(value int) bool {
+		visited++
+		return value%2 != 0
+	})
+	assert.Equal(t, 6, visited)
+	actual := set.ToSlice()
+	slices.Sort(actual)
+	assert.Equal(t, []int{1, 3, 5}, actual)
+	assert.Equal(t, 3, set.Size())
+}
+
+func TestConcurrentSet_GetOrAddAll(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3)
+	added := set.GetOrAddAll(2, 4, 3, 5, 4)
+	assert.Equal(t, []int{4, 5}, added)
+	assert.Equal(t, 5, set.Size())
+	assert.Empty(t, set.GetOrAddAll(1, 2, 3, 4, 5))
+	assert.Empty(t, set.GetOrAddAll())
+}
+
+func TestMapSet(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3, 4, 5)
+	parity := MapSet[int, int](set, func(value int) int { return value % 2 })
+	assert.Equal(t, 2, parity.Size())
+	assert.True(t, parity.Contains(0))
+	assert.True(t, parity.Contains(1))
+	assert.Equal(t, 5, set.Size())
+	empty := MapSet[int, string](NewConcurrentSet[int](), func(value int) string { return fmt.Sprint(value) })
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestConcurrentSet_DrainToList(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3)
+	list := set.DrainToList()
+	assert.True(t, set.IsEmpty())
+	actual := list.ToSlice()
+	slices.Sort(actual)
+	assert.Equal(t, []int{1, 2, 3}, actual)
+	assert.True(t, set.DrainToList().IsEmpty())
+	assert.True(t, set.Add(1))
+}
+
+func TestConcurrentSet_DrainToList_concurrent(t *testing.T) {
+	const producers = 10
+	const count = 1000
+	set := NewConcurrentSet[int]()
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(start int) {
+			defer wg.Done()
+			for i := start; i < start+count; i++ {
+				set.Add(i)
+			}
+		}(p * count)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	drained := make(map[int]int, producers*count)
+	drain := func() {
+		set.DrainToList().ForEach(func(value int) {
+			drained[value]++
+		})
+	}
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+			drain()
+		}
+	}
+	drain()
+	assert.Equal(t, producers*count, len(drained))
+	for value, times := range drained {
+		if times != 1 {
+			t.Errorf("value %d was drained %d times", value, times)
+		}
+	}
+}
+
+func TestConcurrentSet_AddCount(t *testing.T) {
+	set := NewConcurrentSet[int]()
+	assert.Equal(t, uint64(0), set.AddCount())
+	set.Add(1)
+	set.Add(1)
+	set.AddAll(2, 3, 3)
+	set.GetOrAddAll(3, 4)
+	set.AddIfSizeBelow(5, 10)
+	assert.Equal(t, uint64(5), set.AddCount())
+	set.Remove(1)
+	set.Remove(2)
+	set.Add(1)
+	assert.Equal(t, 4, set.Size())
+	assert.Equal(t, uint64(6), set.AddCount())
+	set.Clear()
+	assert.Equal(t, uint64(6), set.AddCount())
+}
+
+func TestConcurrentSet_AddAllStrict(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2)
+	added, duplicate, ok := set.AddAllStrict(3, 4, 5)
+	assert.True(t, ok)
+	assert.Equal(t, 3, added)
+	assert.Equal(t, 0, duplicate)
+	assert.Equal(t, 5, set.Size())
+
+	added, duplicate, ok = set.AddAllStrict(6, 2, 7)
+	assert.False(t, ok)
+	assert.Equal(t, 0, added)
+	assert.Equal(t, 2, duplicate)
+	assert.Equal(t, 5, set.Size())
+	assert.False(t, set.Contains(6))
+
+	added, duplicate, ok = set.AddAllStrict(8, 9, 8)
+	assert.False(t, ok)
+	assert.Equal(t, 0, added)
+	assert.Equal(t, 8, duplicate)
+	assert.False(t, set.Contains(8))
+	assert.False(t, set.Contains(9))
+	assert.Equal(t, uint64(5), set.AddCount())
+}
+
+func TestConcurrentSet_FilterPresent(t *testing.T) {
+	allowlist := NewConcurrentSetWithValues[string]("a", "c", "e")
+	assert.Equal(t, []string{"e", "a", "c", "a"}, allowlist.FilterPresent([]string{"e", "b", "a", "c", "d", "a"}))
+	assert.Empty(t, allowlist.FilterPresent([]string{"x", "y"}))
+	assert.Empty(t, allowlist.FilterPresent(nil))
+}
+
+func TestConcurrentSet_FilterAbsent(t *testing.T) {
+	cached := NewConcurrentSetWithValues[int](1, 3, 5)
+	candidates := []int{5, 2, 1, 4, 3, 6}
+	absent := cached.FilterAbsent(candidates)
+	assert.Equal(t, []int{2, 4, 6}, absent)
+	present := cached.FilterPresent(candidates)
+	assert.ElementsMatch(t, candidates, append(present, absent...))
+	for _, value := range absent {
+		assert.False(t, slices.Contains(present, value))
+	}
+	assert.Equal(t, []int{7}, cached.FilterAbsent([]int{7}))
+}
+
+func TestConcurrentSet_ForEachParallel(t *testing.T) {
+	const count = 1000
+	set := NewConcurrentSetCapacity[int](count)
+	for i := 0; i < count; i++ {
+		set.Add(i)
+	}
+	visits := make([]atomic.Int32, count)
+	var sum atomic.Int64
+	set.ForEachParallel(8, func(value int) {
+		visits[value].Add(1)
+		sum.Add(int64(value))
+		assert.True(t, set.Contains(value))
+	})
+	assert.Equal(t, int64(count*(count-1)/2), sum.Load())
+	for i := range visits {
+		assert.Equal(t, int32(1), visits[i].Load(), "value %d", i)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"errors"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// ErrLengthMismatch error: 'lists have different lengths'
+	ErrLengthMismatch = errors.New("lists have different lengths")
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SumSet returns the sum of all elements of the set or zero if the set is empty.
+//   - cset - the set whose elements are to be summed
+func SumSet[T Number](cset *ConcurrentSet[T]) T {
+	var sum T
+	cset.mu.RLock()
+	for value := range cset.mp {
+		sum += value
+	}
+	cset.mu.RUnlock()
+	return sum
+}
+
+// SumList returns the sum of all elements of the list or zero if the list is empty.
+//   - clist - the list whose elements are to be summed
+func SumList[T Number](clist *ConcurrentLinkedList[T]) T {
+	var sum T
+	clist.mu.RLock()
+	for item := clist.first; item != nil; item = item.next {
+		sum += item.value
+	}
+	clist.mu.RUnlock()
+	return sum
+}
+
+// GroupBy partitions the elements of the list into a ConcurrentMap whose keys are produced by the keyFn function.
+// Each value of the resulting map contains the elements of the corresponding group in the list order.
+//   - clist - the list whose elements are to be grouped
+//   - keyFn - the function that returns the group key for an element
+func GroupBy[T any, K comparable](clist *ConcurrentLinkedList[T], keyFn func(T) K) *ConcurrentMap[K, []T] {
+	groups := make(map[K][]T)
+	clist.mu.RLock()
+	for item := clist.first; item != nil; item = item.next {
+		key := keyFn(item.value)
+		groups[key] = append(groups[key], item.value)
+	}
+	clist.mu.RUnlock()
+	return &ConcurrentMap[K, []T]{mp: groups}
+}
+
+// Tee returns a slice containing all elements of the list in the proper sequence
+// and passes each element to each of the observers, e.g. to log the elements while exporting them.
+// The elements are copied under the read lock, the observers are called after the lock is released,
+// so the observers see exactly the returned snapshot and can use the list methods.
+//   - clist - the list whose elements are to be returned
+//   - observers - the functions, that will be called for each element in the proper sequence
+func Tee[T any](clist *ConcurrentLinkedList[T], observers ...func(value T)) []T {
+	result := clist.ToArray()
+	for _, value := range result {
+		for _, observer := range observers {
+			observer(value)
+		}
+	}
+	return result
+}
+
+// Frequencies returns a ConcurrentMap of the distinct elements of the list to the numbers of their occurrences.
+//   - clist - the list whose elements are to be counted
+func Frequencies[T comparable](clist *ConcurrentLinkedList[T]) *ConcurrentMap[T, int] {
+	counts := make(map[T]int)
+	clist.mu.RLock()
+	for item := clist.first; item != nil; item = item.next {
+		counts[item.value]++
+	}
+	clist.mu.RUnlock()
+	return &ConcurrentMap[T, int]{mp: counts}
+}
+
+// ListToSet returns a new ConcurrentSet containing the distinct elements of the list.
+//   - clist - the list whose elements are to be added to the set
+func ListToSet[T comparable](clist *ConcurrentLinkedList[T]) *ConcurrentSet[T] {
+	clist.mu.RLock()
+	result := NewConcurrentSetCapacity[T](clist.size)
+	for item := clist.first; item != nil; item = item.next {
+		result.mp[item.value] = struct{}{}
+	}
+	clist.mu.RUnlock()
+	return result
+}
+
+// Zip returns a new ConcurrentMap that maps each element of the keys list to the element of the values list
+// at the same position, or nil and ErrLengthMismatch if the lists have different lengths.
+// If the keys list contains duplicates, the value of the last of them is kept.
+// Both lists are read-locked in the order of their addresses, so the result is a consistent snapshot of them.
+//   - keys - the list of keys
+//   - values - the list of values
+func Zip[K comparable, V any](
+	keys *ConcurrentLinkedList[K], values *ConcurrentLinkedList[V],
+) (*ConcurrentMap[K, V], error) {
+	keysPtr, valuesPtr := uintptr(unsafe.Pointer(keys)), uintptr(unsafe.Pointer(values))
+	switch {
+	case keysPtr == valuesPtr:
+		keys.mu.RLock()
+		defer keys.mu.RUnlock()
+	case keysPtr < valuesPtr:
+		keys.mu.RLock()
+		defer keys.mu.RUnlock()
+		values.mu.RLock()
+		defer values.mu.RUnlock()
+	default:
+		values.mu.RLock()
+		defer values.mu.RUnlock()
+		keys.mu.RLock()
+		defer keys.mu.RUnlock()
+	}
+	if keys.size != values.size {
+		return nil, ErrLengthMismatch
+	}
+	result := NewConcurrentMapCapacity[K, V](keys.size)
+	for k, v := keys.first, values.first; k != nil; k, v = k.next, v.next {
+		result.mp[k.value] = v.value
+	}
+	return result, nil
+}
+
+// SetToMap returns a new ConcurrentMap whose keys are the elements of the set
+// and whose values are computed by the valueFn function.
+//   - cset - the set whose elements are to be used as keys
+//   - valueFn - the function that returns the value for a key
+func SetToMap[T comparable, V any](cset *ConcurrentSet[T], valueFn func(T) V) *ConcurrentMap[T, V] {
+	cset.mu.RLock()
+	result := NewConcurrentMapCapacity[T, V](len(cset.mp))
+	for value := range cset.mp {
+		result.mp[value] = valueFn(value)
+	}
+	cset.mu.RUnlock()
+	return result
+}
+
+// FlattenValues returns all the elements of the slices that are the values of the map concatenated together.
+// The order of the slices is unspecified, the elements of each slice keep their order.
+// It can be used to flatten the result of GroupBy.
+//   - cmap - the map whose values are to be flattened
+func FlattenValues[K comparable, V any](cmap *ConcurrentMap[K, []V]) []V {
+	cmap.mu.RLock()
+	defer cmap.mu.RUnlock()
+	total := 0
+	for _, values := range cmap.mp {
+		total += len(values)
+	}
+	result := make([]V, 0, total)
+	for _, values := range cmap.mp {
+		result = append(result, values...)
+	}
+	return result
+}
+
+// forEachParallel calls the function f for each of the values using the specified number of goroutines
+// and waits for all of them to complete.
+func forEachParallel[T any](values []T, workers int, f func(value T)) {
+	workers = max(1, min(workers, len(values)))
+	ch := make(chan T, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for value := range ch {
+				f(value)
+			}
+		}()
+	}
+	for _, value := range values {
+		ch <- value
+	}
+	close(ch)
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSumSet(t *testing.T) {
+	assert.Equal(t, 10, SumSet(NewConcurrentSetWithValues[int](1, 2, 3, 4)))
+	assert.InDelta(t, 4.0, SumSet(NewConcurrentSetWithValues[float64](1.5, 2.5)), 1e-9)
+	assert.Equal(t, 0, SumSet(NewConcurrentSet[int]()))
+	assert.Equal(t, 0.0, SumSet(NewConcurrentSet[float64]()))
+}
+
+func TestSumList(t *testing.T) {
+	assert.Equal(t, 12, SumList(NewConcurrentLinkedListItems[int](1, 2, 3, 3, 3)))
+	assert.InDelta(t, 5.5, SumList(NewConcurrentLinkedListItems[float64](1.5, 2.5, 1.5)), 1e-9)
+	assert.Equal(t, 0, SumList(NewConcurrentLinkedList[int]()))
+	assert.Equal(t, 0.0, SumList(NewConcurrentLinkedList[float64]()))
+}
+
+func TestGroupBy(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5, 6, 7)
+	groups := GroupBy(list, func(value int) bool { return value%2 == 0 })
+	assert.Equal(t, 2, groups.Size())
+	even, ok := groups.Get(true)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 4, 6}, even)
+	odd, ok := groups.Get(false)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 3, 5, 7}, odd)
+
+	empty := GroupBy(NewConcurrentLinkedList[int](), func(value int) int { return value })
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestListToSet(t *testing.T) {
+	list := NewConcurrentLinkedListItems[string]("a", "b", "a", "c", "b", "a")
+	set := ListToSet(list)
+	assert.Equal(t, 3, set.Size())
+	for _, value := range []string{"a", "b", "c"} {
+		assert.True(t, set.Contains(value), value)
+	}
+	assert.Equal(t, 6, list.Size(), "the list must not be changed")
+	assert.True(t, ListToSet(NewConcurrentLinkedList[string]()).IsEmpty())
+}
+
+func TestZip(t *testing.T) {
+	keys := NewConcurrentLinkedListItems[string]("a", "b", "c")
+	values := NewConcurrentLinkedListItems[int](1, 2, 3)
+	actual, err := Zip(keys, values)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, actual.Copy())
+
+	same := NewConcurrentLinkedListItems[int](1, 2)
+	selfZipped, err := Zip(same, same)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, selfZipped.Copy())
+
+	empty, err := Zip(NewConcurrentLinkedList[string](), NewConcurrentLinkedList[int]())
+	assert.Nil(t, err)
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestZip_length_mismatch(t *testing.T) {
+	keys := NewConcurrentLinkedListItems[string]("a", "b", "c")
+	values := NewConcurrentLinkedListItems[int](1, 2)
+	actual, err := Zip(keys, values)
+	assert.ErrorIs(t, err, ErrLengthMismatch)
+	assert.Nil(t, actual)
+}
+
+func TestSetToMap(t *testing.T) {
+	set := NewConcurrentSetWithValues[int](1, 2, 3)
+	actual := SetToMap(set, func(value int) string { return fmt.Sprint("id-", value) })
+	assert.Equal(t, map[int]string{1: "id-1", 2: "id-2", 3: "id-3"}, actual.Copy())
+	assert.True(t, SetToMap(NewConcurrentSet[int](), func(value int) int { return value }).IsEmpty())
+}
+
+func TestFlattenValues(t *testing.T) {
+	cm := NewConcurrentMap[string, []int]()
+	cm.Put("odd", []int{1, 3, 5})
+	cm.Put("even", []int{2, 4})
+	cm.Put("empty", nil)
+	actual := FlattenValues(cm)
+	assert.Equal(t, 5, len(actual))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, actual)
+
+	groups := GroupBy(NewConcurrentLinkedListItems[int](1, 2, 3, 4, 5, 6), func(value int) int { return value % 3 })
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, FlattenValues(groups))
+	assert.Empty(t, FlattenValues(NewConcurrentMap[string, []int]()))
+}
+
+func TestFrequencies(t *testing.T) {
+	list := NewConcurrentLinkedListItems[string]("a", "b", "a", "c", "a", "b")
+	actual := Frequencies(list)
+	assert.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, actual.Copy())
+	actual.Put("d", 1)
+	assert.Equal(t, 4, actual.Size())
+	assert.True(t, Frequencies(NewConcurrentLinkedList[string]()).IsEmpty())
+}
+
+func TestTee(t *testing.T) {
+	list := NewConcurrentLinkedListItems[int](1, 2, 3)
+	var logged []string
+	sum := 0
+	actual := Tee(list,
+		func(value int) { logged = append(logged, fmt.Sprint("value ", value)) },
+		func(value int) { sum += value },
+	)
+	assert.Equal(t, list.ToArray(), actual)
+	assert.Equal(t, []string{"value 1", "value 2", "value 3"}, logged)
+	assert.Equal(t, 6, sum)
+	assert.Equal(t, []int{1, 2, 3}, Tee(list))
+	assert.Empty(t, Tee(NewConcurrentLinkedList[int](), func(value int) { t.Fail() }))
+}
@@ -0,0 +1,96 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import "sync"
+
+// HashSet is a thread safe set of values of any type, including types that are not comparable
+// (slices, maps, functions and structures containing them).
+// The values are distributed into buckets by the hash function and compared by the equal function,
+// values with the same hash are kept in the same bucket (collision chain).
+// HashSet is safe for concurrent use by multiple goroutines.
+//   - T - value type
+type HashSet[T any] struct {
+	mu      sync.RWMutex
+	buckets map[uint64][]T
+	size    int
+	hash    func(T) uint64
+	equal   func(a, b T) bool
+}
+
+func (hset *HashSet[T]) indexOf(bucket []T, value T) int {
+	for i, v := range bucket {
+		if hset.equal(v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add adds a specified value to the set.
+// Returns true if the value did not exist and was added to the set, otherwise returns false.
+func (hset *HashSet[T]) Add(value T) bool {
+	h := hset.hash(value)
+	hset.mu.Lock()
+	defer hset.mu.Unlock()
+	bucket := hset.buckets[h]
+	if hset.indexOf(bucket, value) >= 0 {
+		return false
+	}
+	hset.buckets[h] = append(bucket, value)
+	hset.size++
+	return true
+}
+
+// Remove removes a value from the set.
+// Returns true if this set changed as result of the call.
+//
+//revive:disable:confusing-naming
+func (hset *HashSet[T]) Remove(value T) bool {
+	h := hset.hash(value)
+	hset.mu.Lock()
+	defer hset.mu.Unlock()
+	bucket := hset.buckets[h]
+	index := hset.indexOf(bucket, value)
+	if index < 0 {
+		return false
+	}
+	if len(bucket) == 1 {
+		delete(hset.buckets, h)
+	} else {
+		last := len(bucket) - 1
+		bucket[index] = bucket[last]
+		var zero T
+		bucket[last] = zero
+		hset.buckets[h] = bucket[:last]
+	}
+	hset.size--
+	return true
+} //revive:enable:confusing-naming
+
+// Contains returns true if the set contains the value
+func (hset *HashSet[T]) Contains(value T) bool {
+	h := hset.hash(value)
+	hset.mu.RLock()
+	defer hset.mu.RUnlock()
+	return hset.indexOf(hset.buckets[h], value) >= 0
+}
+
+// Size returns the current size of the set.
+//
+//revive:disable:confusing-naming
+func (hset *HashSet[T]) Size() int {
+	hset.mu.RLock()
+	defer hset.mu.RUnlock()
+	return hset.size
+} //revive:enable:confusing-naming
+
+// NewHashSet returns a new empty HashSet instance
+//   - T - value type
+//   - hash - the function that returns the hash of a value, equal values must have the same hash
+//   - equal - the function that returns true if the values are equal
+func NewHashSet[T any](hash func(T) uint64, equal func(a, b T) bool) *HashSet[T] {
+	return &HashSet[T]{buckets: make(map[uint64][]T), hash: hash, equal: equal}
+}
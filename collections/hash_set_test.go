@@ -0,0 +1,63 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hash/fnv"
+	"slices"
+	"testing"
+)
+
+type hashSetTestStruct struct {
+	name string
+	tags []string
+}
+
+func hashSetTestHash(value hashSetTestStruct) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value.name))
+	for _, tag := range value.tags {
+		_, _ = h.Write([]byte(tag))
+	}
+	return h.Sum64()
+}
+
+func hashSetTestEqual(a, b hashSetTestStruct) bool {
+	return a.name == b.name && slices.Equal(a.tags, b.tags)
+}
+
+func TestHashSet(t *testing.T) {
+	set := NewHashSet[hashSetTestStruct](hashSetTestHash, hashSetTestEqual)
+	value1 := hashSetTestStruct{name: "value", tags: []string{"a", "b"}}
+	value2 := hashSetTestStruct{name: "value", tags: []string{"a"}}
+
+	assert.True(t, set.Add(value1))
+	assert.False(t, set.Add(hashSetTestStruct{name: "value", tags: []string{"a", "b"}}))
+	assert.True(t, set.Add(value2))
+	assert.Equal(t, 2, set.Size())
+	assert.True(t, set.Contains(hashSetTestStruct{name: "value", tags: []string{"a", "b"}}))
+	assert.False(t, set.Contains(hashSetTestStruct{name: "value", tags: []string{"b"}}))
+
+	assert.True(t, set.Remove(value1))
+	assert.False(t, set.Remove(value1))
+	assert.False(t, set.Contains(value1))
+	assert.True(t, set.Contains(value2))
+	assert.Equal(t, 1, set.Size())
+}
+
+func TestHashSet_collisions(t *testing.T) {
+	set := NewHashSet[[]int](func([]int) uint64 { return 1 }, slices.Equal[[]int])
+	for i := 0; i < 5; i++ {
+		assert.True(t, set.Add([]int{i, i}))
+	}
+	assert.Equal(t, 5, set.Size())
+	assert.True(t, set.Remove([]int{1, 1}))
+	assert.True(t, set.Remove([]int{4, 4}))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, i != 1 && i != 4, set.Contains([]int{i, i}), i)
+	}
+	assert.Equal(t, 3, set.Size())
+}
@@ -0,0 +1,60 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+// MapGroup is an aggregate read-only view of several ConcurrentMap instances,
+// for example, of the maps across which the data is sharded by the user.
+// The maps are not locked together, so the aggregate results are not an atomic snapshot of the group.
+// MapGroup is safe for concurrent use by multiple goroutines.
+//   - K - comparable key type
+//   - V - value type
+type MapGroup[K comparable, V any] struct {
+	maps []*ConcurrentMap[K, V]
+}
+
+// Get searches the maps of the group in the order they were specified and returns the first value
+// to which the specified key is mapped and true, otherwise the default value for the value type and false.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (mgroup *MapGroup[K, V]) Get(key K) (V, bool) {
+	for _, cmap := range mgroup.maps {
+		if val, ok := cmap.Get(key); ok {
+			return val, true
+		}
+	}
+	var res V
+	return res, false
+} //revive:enable:confusing-naming
+
+// TotalSize returns the sum of the sizes of the maps of the group
+func (mgroup *MapGroup[K, V]) TotalSize() int {
+	total := 0
+	for _, cmap := range mgroup.maps {
+		total += cmap.Size()
+	}
+	return total
+}
+
+// ForEach performs a given action for each (key, value) pair of each map of the group
+// in the order the maps were specified.
+//   - f - the function, that will be called for each (key, value) pair
+//
+// Each map is iterated under its read lock (see ConcurrentMap.ForEachRead).
+//
+//revive:disable:confusing-naming
+func (mgroup *MapGroup[K, V]) ForEach(f func(key K, value V)) {
+	for _, cmap := range mgroup.maps {
+		cmap.ForEachRead(f)
+	}
+} //revive:enable:confusing-naming
+
+// NewMapGroup returns a new MapGroup of the specified maps
+//   - maps - the maps of the group
+func NewMapGroup[K comparable, V any](maps ...*ConcurrentMap[K, V]) *MapGroup[K, V] {
+	group := make([]*ConcurrentMap[K, V], len(maps))
+	copy(group, maps)
+	return &MapGroup[K, V]{maps: group}
+}
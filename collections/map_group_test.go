@@ -0,0 +1,44 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMapGroup(t *testing.T) {
+	first := NewConcurrentMap[string, int]()
+	first.Put("one", 1)
+	first.Put("two", 2)
+	second := NewConcurrentMap[string, int]()
+	second.Put("three", 3)
+	second.Put("two", 22)
+	group := NewMapGroup[string, int](first, second)
+
+	actual, ok := group.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, actual)
+	actual, ok = group.Get("three")
+	assert.True(t, ok)
+	assert.Equal(t, 3, actual)
+	actual, ok = group.Get("two")
+	assert.True(t, ok)
+	assert.Equal(t, 2, actual, "the first map should take precedence")
+	actual, ok = group.Get("four")
+	assert.False(t, ok)
+	assert.Equal(t, 0, actual)
+
+	assert.Equal(t, 4, group.TotalSize())
+	second.Put("four", 4)
+	assert.Equal(t, 5, group.TotalSize())
+
+	sum := 0
+	group.ForEach(func(key string, value int) {
+		sum += value
+	})
+	assert.Equal(t, 1+2+3+22+4, sum)
+	assert.Equal(t, 0, NewMapGroup[string, int]().TotalSize())
+}
@@ -0,0 +1,111 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ReadOptimizedSet is a thread safe set optimized for read-heavy workloads.
+// The set stores an immutable map that is replaced on each modification (copy-on-write),
+// so Contains, Size and ToSlice are lock-free, while Add, AddAll and Remove copy the whole set.
+// It suits sets that are read very often and changed rarely (e.g. allow lists).
+// ReadOptimizedSet is safe for concurrent use by multiple goroutines.
+//   - T - value type
+type ReadOptimizedSet[T comparable] struct {
+	mu sync.Mutex
+	mp atomic.Pointer[map[T]struct{}]
+}
+
+func (rset *ReadOptimizedSet[T]) load() map[T]struct{} {
+	return *rset.mp.Load()
+}
+
+// Add adds a specified value to the set.
+// Returns true if the value did not exist and was added to the set, otherwise returns false.
+func (rset *ReadOptimizedSet[T]) Add(value T) bool {
+	return rset.AddAll(value)
+}
+
+// AddAll adds all the specified values to the set.
+// Returns true if this set changed as result of the call.
+func (rset *ReadOptimizedSet[T]) AddAll(values ...T) bool {
+	rset.mu.Lock()
+	defer rset.mu.Unlock()
+	old := rset.load()
+	var tmp map[T]struct{}
+	for _, value := range values {
+		if _, ok := old[value]; ok {
+			continue
+		}
+		if tmp == nil {
+			tmp = make(map[T]struct{}, len(old)+len(values))
+			for k := range old {
+				tmp[k] = struct{}{}
+			}
+		}
+		tmp[value] = struct{}{}
+	}
+	if tmp == nil {
+		return false
+	}
+	rset.mp.Store(&tmp)
+	return true
+}
+
+// Remove removes a value from the set.
+// Returns true if this set changed as result of the call.
+//
+//revive:disable:confusing-naming
+func (rset *ReadOptimizedSet[T]) Remove(value T) bool {
+	rset.mu.Lock()
+	defer rset.mu.Unlock()
+	old := rset.load()
+	if _, ok := old[value]; !ok {
+		return false
+	}
+	tmp := make(map[T]struct{}, len(old)-1)
+	for k := range old {
+		if k != value {
+			tmp[k] = struct{}{}
+		}
+	}
+	rset.mp.Store(&tmp)
+	return true
+} //revive:enable:confusing-naming
+
+// Contains returns true if the set contains the value
+func (rset *ReadOptimizedSet[T]) Contains(value T) bool {
+	_, ok := rset.load()[value]
+	return ok
+}
+
+// Size returns the current size of the set.
+func (rset *ReadOptimizedSet[T]) Size() int {
+	return len(rset.load())
+}
+
+// ToSlice returns a slice of the set elements
+func (rset *ReadOptimizedSet[T]) ToSlice() []T {
+	mp := rset.load()
+	result := make([]T, 0, len(mp))
+	for k := range mp {
+		result = append(result, k)
+	}
+	return result
+}
+
+// NewReadOptimizedSet returns a new ReadOptimizedSet instance containing specified values
+//   - values ...T - values that the ReadOptimizedSet will contain
+func NewReadOptimizedSet[T comparable](values ...T) *ReadOptimizedSet[T] {
+	mp := make(map[T]struct{}, len(values))
+	for _, value := range values {
+		mp[value] = struct{}{}
+	}
+	result := &ReadOptimizedSet[T]{}
+	result.mp.Store(&mp)
+	return result
+}
@@ -0,0 +1,40 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"testing"
+)
+
+func BenchmarkReadOptimizedSet_Contains(b *testing.B) {
+	const count = 1000
+	values := make([]int, count)
+	for i := range values {
+		values[i] = i
+	}
+	benchmarks := []struct {
+		name     string
+		contains func(value int) bool
+	}{
+		{name: "ConcurrentSet", contains: NewConcurrentSetWithValues[int](values...).Contains},
+		{name: "ReadOptimizedSet", contains: NewReadOptimizedSet[int](values...).Contains},
+	}
+	for _, bm := range benchmarks {
+		bmv := bm
+		b.Run(bmv.name, func(b *testing.B) {
+			b.SetParallelism(100)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if !bmv.contains(i % count) {
+						b.Error("the value must exist:", i%count)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestReadOptimizedSet(t *testing.T) {
+	set := NewReadOptimizedSet[int](1, 2)
+	assert.Equal(t, 2, set.Size())
+	assert.True(t, set.Add(3))
+	assert.False(t, set.Add(3))
+	assert.True(t, set.AddAll(3, 4))
+	assert.False(t, set.AddAll(1, 4))
+	assert.True(t, set.Contains(4))
+	assert.True(t, set.Remove(1))
+	assert.False(t, set.Remove(1))
+	assert.False(t, set.Contains(1))
+	actual := set.ToSlice()
+	slices.Sort(actual)
+	assert.Equal(t, []int{2, 3, 4}, actual)
+	assert.Equal(t, 3, set.Size())
+}
+
+func TestReadOptimizedSet_concurrent(t *testing.T) {
+	const (
+		count   = 200
+		readers = 8
+	)
+	set := NewReadOptimizedSet[int]()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lastEvens, lastSize := 0, 0
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				size := set.Size()
+				values := set.ToSlice()
+				slices.Sort(values)
+				assert.Equal(t, len(values), len(slices.Compact(slices.Clone(values))), "duplicates:", values)
+				evens := assertReadOptimizedSnapshot(t, values)
+				assert.GreaterOrEqual(t, evens, lastEvens, "even values must never disappear")
+				lastEvens = evens
+				if evens > 0 {
+					assert.True(t, set.Contains(2*(evens-1)), "an added even value must stay in the set")
+				}
+				assert.GreaterOrEqual(t, size, lastSize-1, "the size may only shrink by the removed odd value")
+				lastSize = size
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		set.Add(i)
+		if i%2 == 1 {
+			set.Remove(i)
+		}
+	}
+	close(done)
+	wg.Wait()
+	assert.Equal(t, count/2, set.Size())
+	for i := 0; i < count; i++ {
+		assert.Equal(t, i%2 == 0, set.Contains(i), i)
+	}
+}
+
+// assertReadOptimizedSnapshot checks a sorted snapshot of the set filled by TestReadOptimizedSet_concurrent:
+// it must contain the even values 0, 2, ..., 2m and at most one odd value 2m+1, which is added and then removed
+// by the writer before the next even value is added. Returns the number of the even values.
+func assertReadOptimizedSnapshot(t *testing.T, values []int) int {
+	evens := 0
+	for i, value := range values {
+		if value%2 == 0 {
+			assert.Equal(t, 2*evens, value, "the even values must be contiguous:", values)
+			evens++
+			continue
+		}
+		assert.Equal(t, len(values)-1, i, "an odd value must be the last one:", values)
+		assert.Equal(t, 2*evens-1, value, "only the latest odd value may be present:", values)
+	}
+	return evens
+}
@@ -0,0 +1,76 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import "sync"
+
+// SlidingWindowSet is a thread safe set that contains only the values added within the last N additions (window).
+// When a new value is added to a full window, the oldest addition falls out of the window,
+// and its value is removed from the set unless it was added again within the window.
+// It is useful for deduplicating recent events, e.g. idempotency keys seen in the last N requests.
+// SlidingWindowSet is safe for concurrent use by multiple goroutines.
+//   - T - value type
+type SlidingWindowSet[T comparable] struct {
+	mu     sync.RWMutex
+	counts map[T]int
+	ring   []T
+	next   int
+	filled bool
+}
+
+// Add adds a specified value to the window, evicting the oldest addition if the window is full.
+// Returns true if the value wasn't in the window before the call, otherwise returns false.
+func (wset *SlidingWindowSet[T]) Add(value T) bool {
+	wset.mu.Lock()
+	defer wset.mu.Unlock()
+	if wset.filled {
+		oldest := wset.ring[wset.next]
+		if wset.counts[oldest] <= 1 {
+			delete(wset.counts, oldest)
+		} else {
+			wset.counts[oldest]--
+		}
+	}
+	_, exists := wset.counts[value]
+	wset.counts[value]++
+	wset.ring[wset.next] = value
+	wset.next++
+	if wset.next == len(wset.ring) {
+		wset.next = 0
+		wset.filled = true
+	}
+	return !exists
+}
+
+// Contains returns true if the value was added within the current window
+func (wset *SlidingWindowSet[T]) Contains(value T) bool {
+	wset.mu.RLock()
+	_, res := wset.counts[value]
+	wset.mu.RUnlock()
+	return res
+}
+
+// Size returns the number of distinct values in the current window.
+//
+//revive:disable:confusing-naming
+func (wset *SlidingWindowSet[T]) Size() int {
+	wset.mu.RLock()
+	defer wset.mu.RUnlock()
+	return len(wset.counts)
+} //revive:enable:confusing-naming
+
+// Window returns the number of the last additions kept by this set
+func (wset *SlidingWindowSet[T]) Window() int {
+	return len(wset.ring)
+}
+
+// NewSlidingWindowSet returns a new empty SlidingWindowSet instance.
+// A window less than 1 is treated as 1.
+//   - T - value type
+//   - window - the number of the last additions whose values are kept in the set
+func NewSlidingWindowSet[T comparable](window int) *SlidingWindowSet[T] {
+	window = max(window, 1)
+	return &SlidingWindowSet[T]{counts: make(map[T]int, window), ring: make([]T, window)}
+}
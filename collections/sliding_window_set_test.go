@@ -0,0 +1,50 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSlidingWindowSet_Add(t *testing.T) {
+	wset := NewSlidingWindowSet[string](3)
+	assert.Equal(t, 3, wset.Window())
+	assert.True(t, wset.Add("a"))
+	assert.True(t, wset.Add("b"))
+	assert.False(t, wset.Add("a"))
+	assert.Equal(t, 2, wset.Size())
+
+	assert.True(t, wset.Add("c"))
+	assert.True(t, wset.Contains("b"))
+	assert.True(t, wset.Contains("a"), "'a' was added again within the window")
+	assert.Equal(t, 3, wset.Size())
+
+	assert.True(t, wset.Add("d"))
+	assert.False(t, wset.Contains("b"), "'b' should fall out of the window")
+	assert.True(t, wset.Add("e"))
+	assert.False(t, wset.Contains("a"))
+	assert.True(t, wset.Add("a"))
+	assert.False(t, wset.Contains("c"))
+	assert.Equal(t, 3, wset.Size())
+	assert.True(t, wset.Contains("d"))
+	assert.True(t, wset.Contains("e"))
+}
+
+func TestSlidingWindowSet_eviction(t *testing.T) {
+	const window = 100
+	wset := NewSlidingWindowSet[int](window)
+	for i := 0; i < window*3; i++ {
+		wset.Add(i)
+	}
+	assert.Equal(t, window, wset.Size())
+	for i := 0; i < window*2; i++ {
+		assert.False(t, wset.Contains(i), i)
+	}
+	for i := window * 2; i < window*3; i++ {
+		assert.True(t, wset.Contains(i), i)
+	}
+	assert.Equal(t, 1, NewSlidingWindowSet[int](0).Window())
+}
@@ -0,0 +1,144 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !now.Before(e.expiresAt)
+}
+
+// TTLMap is a thread safe map whose entries expire after a specified time to live (TTL).
+// Expired entries are treated as absent and are periodically removed by a background goroutine (janitor).
+// The Close method should be called to stop the janitor when the map is no longer needed.
+// A TTLMap is safe for concurrent use by multiple goroutines.
+//   - K - comparable key type;
+//   - V - value type.
+type TTLMap[K comparable, V any] struct {
+	mu      sync.RWMutex
+	mp      map[K]ttlEntry[V]
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// PutWithTTL maps the specified key to the specified value for the specified time to live.
+//   - key - the key with which a specified value is to be assigned
+//   - value - the value to be associated with the specified key
+//   - ttl - the time after which the mapping expires
+func (tmap *TTLMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	entry := ttlEntry[V]{value: value, expiresAt: time.Now().Add(ttl)}
+	tmap.mu.Lock()
+	tmap.mp[key] = entry
+	tmap.mu.Unlock()
+}
+
+// Get returns the value to which the specified key is mapped and true if the mapping exists and hasn't expired,
+// otherwise the default value for the value type and false are returned.
+//   - key - the key whose value will be returned
+//
+//revive:disable:confusing-naming
+func (tmap *TTLMap[K, V]) Get(key K) (V, bool) {
+	tmap.mu.RLock()
+	entry, ok := tmap.mp[key]
+	tmap.mu.RUnlock()
+	if !ok || entry.expired(time.Now()) {
+		var res V
+		return res, false
+	}
+	return entry.value, true
+} //revive:enable:confusing-naming
+
+// Remove removes the key and its corresponding value from the TTLMap.
+//   - key - the key that needs to be removed
+//
+//revive:disable:confusing-naming
+func (tmap *TTLMap[K, V]) Remove(key K) {
+	tmap.mu.Lock()
+	delete(tmap.mp, key)
+	tmap.mu.Unlock()
+} //revive:enable:confusing-naming
+
+// Size returns the number of key-value mappings in this map that haven't expired.
+// Since the expiration of each mapping is checked, the complexity of this method is O(n).
+//
+//revive:disable:confusing-naming
+func (tmap *TTLMap[K, V]) Size() int {
+	now := time.Now()
+	size := 0
+	tmap.mu.RLock()
+	for _, entry := range tmap.mp {
+		if !entry.expired(now) {
+			size++
+		}
+	}
+	tmap.mu.RUnlock()
+	return size
+} //revive:enable:confusing-naming
+
+// Close stops the janitor goroutine. It is safe to call Close several times.
+func (tmap *TTLMap[K, V]) Close() {
+	tmap.once.Do(func() {
+		close(tmap.stop)
+		<-tmap.stopped
+	})
+}
+
+func (tmap *TTLMap[K, V]) removeExpired() int {
+	now := time.Now()
+	removed := 0
+	tmap.mu.Lock()
+	for key, entry := range tmap.mp {
+		if entry.expired(now) {
+			delete(tmap.mp, key)
+			removed++
+		}
+	}
+	tmap.mu.Unlock()
+	return removed
+}
+
+func (tmap *TTLMap[K, V]) janitor(interval time.Duration) {
+	defer close(tmap.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			tmap.removeExpired()
+		case <-tmap.stop:
+			return
+		}
+	}
+}
+
+// DefaultTTLMapCleanupInterval is the cleanup interval used by NewTTLMap when a non-positive interval is specified
+const DefaultTTLMapCleanupInterval = time.Minute
+
+// NewTTLMap creates and returns a new empty TTLMap instance and starts its janitor goroutine.
+// If cleanupInterval is not positive, DefaultTTLMapCleanupInterval is used instead.
+//   - K - comparable key type;
+//   - V - value type;
+//   - cleanupInterval - the interval between removals of the expired entries.
+func NewTTLMap[K comparable, V any](cleanupInterval time.Duration) *TTLMap[K, V] {
+	if cleanupInterval <= 0 {
+		cleanupInterval = DefaultTTLMapCleanupInterval
+	}
+	result := &TTLMap[K, V]{
+		mp:      make(map[K]ttlEntry[V]),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go result.janitor(cleanupInterval)
+	return result
+}
@@ -0,0 +1,84 @@
+// Copyright Ⓒ 2023 Pavlo Moisieienko. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collections
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTTLMap_Get_expired(t *testing.T) {
+	tm := NewTTLMap[int, string](time.Hour)
+	defer tm.Close()
+	tm.PutWithTTL(1, "short", 20*time.Millisecond)
+	tm.PutWithTTL(2, "long", time.Hour)
+
+	actual, ok := tm.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "short", actual)
+	assert.Equal(t, 2, tm.Size())
+
+	time.Sleep(30 * time.Millisecond)
+
+	actual, ok = tm.Get(1)
+	assert.False(t, ok, "the expired entry must be absent")
+	assert.Equal(t, "", actual)
+	actual, ok = tm.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "long", actual)
+	assert.Equal(t, 1, tm.Size())
+}
+
+func TestTTLMap_janitor(t *testing.T) {
+	tm := NewTTLMap[int, string](5 * time.Millisecond)
+	defer tm.Close()
+	tm.PutWithTTL(1, "value 1", 10*time.Millisecond)
+	tm.PutWithTTL(2, "value 2", time.Hour)
+	assert.Eventually(t, func() bool {
+		tm.mu.RLock()
+		defer tm.mu.RUnlock()
+		return len(tm.mp) == 1
+	}, time.Second, time.Millisecond)
+	_, ok := tm.Get(2)
+	assert.True(t, ok)
+}
+
+func TestTTLMap_Remove(t *testing.T) {
+	tm := NewTTLMap[int, string](time.Hour)
+	defer tm.Close()
+	tm.PutWithTTL(1, "value 1", time.Hour)
+	tm.Remove(1)
+	_, ok := tm.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, tm.Size())
+}
+
+func TestTTLMap_Close(t *testing.T) {
+	tm := NewTTLMap[int, string](time.Millisecond)
+	tm.Close()
+	select {
+	case <-tm.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the janitor goroutine is still running")
+	}
+	tm.Close()
+}
+
+func TestNewTTLMap_nonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		tm := NewTTLMap[int, string](interval)
+		tm.PutWithTTL(1, "value 1", time.Hour)
+		actual, ok := tm.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "value 1", actual)
+		tm.Close()
+		select {
+		case <-tm.stopped:
+		case <-time.After(time.Second):
+			t.Fatal("the janitor goroutine is still running, interval:", interval)
+		}
+	}
+}